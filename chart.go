@@ -0,0 +1,48 @@
+package exceltable
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// AddChart adds chart at cell, plotting the data rows of the value columns against the category column.
+// Columns are referred to by header value or field name, and each value column becomes a series named by its header:
+//
+//	_ = s.AddChart("H2", &excelize.Chart{Type: excelize.Line}, "Date", "Revenue")
+//
+// The series of chart are replaced, and the other settings, such as the title and the size, are used as is.
+// It returns ErrUnknownColumn if any column is not found.
+//
+// It must be called after writing all data rows, and for SheetWithStreamWriter, before Flush.
+func (s *sheetBase[M]) AddChart(cell string, chart *excelize.Chart, category string, values ...string) error {
+	catCol, ok := s.columnByHeader(category)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownColumn, category)
+	}
+
+	c := *chart
+	c.Series = make([]excelize.ChartSeries, 0, len(values))
+	for _, name := range values {
+		col, ok := s.columnByHeader(name)
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, name)
+		}
+		c.Series = append(c.Series, excelize.ChartSeries{
+			Name:       s.qualifiedRef(s.coordinatesToCellName(col, 0, true)),
+			Categories: s.qualifiedRef(s.absColumnRangeRef(catCol)),
+			Values:     s.qualifiedRef(s.absColumnRangeRef(col)),
+		})
+	}
+	return s.File.AddChart(s.name, cell, &c)
+}
+
+// absColumnRangeRef returns the absolute range reference of the data cells of column col, such as "$B$2:$B$10".
+func (s *sheetBase[M]) absColumnRangeRef(col int) string {
+	return fmt.Sprintf("%s:%s", s.coordinatesToCellName(col, 1, true), s.coordinatesToCellName(col, max(s.row-1, 1), true))
+}
+
+// qualifiedRef qualifies ref with the sheet name, such as 'Sheet 1'!$B$2:$B$10.
+func (s *sheetBase[M]) qualifiedRef(ref string) string {
+	return quoteSheetName(s.name) + "!" + ref
+}
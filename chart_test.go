@@ -0,0 +1,62 @@
+package exceltable
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestSheet_AddChart(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	s, err := NewSheet[person](f, "Weekly KPI", "B2", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	for _, p := range persons {
+		require.NoError(t, s.SetRow(p))
+	}
+
+	chart := &excelize.Chart{Type: excelize.Line}
+	require.NoError(t, s.AddChart("H2", chart, "氏名", "Age"))
+	assert.Empty(t, chart.Series)
+
+	b, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	require.True(t, ok)
+	xml := string(b.([]byte))
+	assert.Contains(t, xml, "<f>&#39;Weekly KPI&#39;!$D$2</f>")
+	assert.Contains(t, xml, "<f>&#39;Weekly KPI&#39;!$C$3:$C$5</f>")
+	assert.Contains(t, xml, "<f>&#39;Weekly KPI&#39;!$D$3:$D$5</f>")
+
+	assert.ErrorIs(t, s.AddChart("H20", chart, "Date", "Age"), ErrUnknownColumn)
+	assert.ErrorIs(t, s.AddChart("H20", chart, "氏名", "Revenue"), ErrUnknownColumn)
+}
+
+func TestSheetWithStreamWriter_AddChart(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	ssw, err := NewSheetWithStreamWriter[person](f, "NewSheet", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, ssw.SetHeader())
+	for _, p := range persons {
+		require.NoError(t, ssw.SetRow(p))
+	}
+	require.NoError(t, ssw.AddDefaultTable())
+	require.NoError(t, ssw.AddChart("H2", &excelize.Chart{Type: excelize.Col}, "ID", "年齢"))
+	require.NoError(t, ssw.Flush())
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	saved, err := OpenFile(path)
+	require.NoError(t, err)
+	b, ok := saved.Pkg.Load("xl/charts/chart1.xml")
+	require.True(t, ok)
+	assert.Contains(t, string(b.([]byte)), "<f>&#39;NewSheet&#39;!$C$2:$C$4</f>")
+	sheet, ok := saved.Pkg.Load("xl/worksheets/sheet2.xml")
+	require.True(t, ok)
+	assert.Contains(t, string(sheet.([]byte)), "<drawing ")
+}
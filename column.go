@@ -0,0 +1,57 @@
+package exceltable
+
+import "github.com/xuri/excelize/v2"
+
+// Options of the excel tag indicating column visibility.
+const (
+	hiddenOption  string = "hidden"  // hide the column
+	outlineOption string = "outline" // group the column with outline level 1
+)
+
+// hiddenColumns returns the columns of sc with the "hidden" option.
+func hiddenColumns(sc *schema) []int {
+	cols := make([]int, 0)
+	for col, i := range sc.fields {
+		if hasTagOption(sc.typ.Field(i), excelTag, hiddenOption) {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// columnName returns the column name of column col, such as "B".
+func (s *sheetBase[M]) columnName(col int) string {
+	name, err := excelize.ColumnNumberToName(s.x + col)
+	if err != nil {
+		panic(err) // This should never happen when col is non-negative.
+	}
+	return name
+}
+
+// hideColumns hides the columns with the "hidden" option,
+// and groups those with the "outline" option as well.
+func (s *Sheet[M]) hideColumns() error {
+	for _, col := range hiddenColumns(s.schema) {
+		name := s.columnName(col)
+		if err := s.File.SetColVisible(s.name, name, false); err != nil {
+			return err
+		}
+		if hasTagOption(s.typ.Field(s.fields[col]), excelTag, outlineOption) {
+			if err := s.File.SetColOutlineLevel(s.name, name, 1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hideColumns hides the columns with the "hidden" option by setting their widths to zero,
+// since excelize.StreamWriter cannot write column visibility. The "outline" option is ignored.
+func (ssw *SheetWithStreamWriter[M]) hideColumns() error {
+	for _, col := range hiddenColumns(ssw.schema) {
+		if err := ssw.StreamWriter.SetColWidth(ssw.x+col, ssw.x+col, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
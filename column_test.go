@@ -0,0 +1,80 @@
+package exceltable
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type customer struct {
+	ID            string `excel:"ID"`
+	Name          string `excel:"Name"`
+	AccountNumber string `excel:"Account,hidden,outline"`
+	InternalCode  string `excel:",hidden"`
+}
+
+var customers = []*customer{
+	{"C-1", "Alice", "0000-0000", "X1"},
+	{"C-2", "Bob", "1111-1111", "X2"},
+}
+
+func TestSheet_HiddenColumns(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[customer](f, "test", "B2", true)
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetHeader())
+	for _, c := range customers {
+		require.NoError(t, s.SetRow(c))
+	}
+	require.NoError(t, s.Flush())
+
+	for col, want := range map[string]bool{"B": true, "C": true, "D": false, "E": false} {
+		visible, err := f.GetColVisible("test", col)
+		require.NoError(t, err)
+		assert.Equal(t, want, visible, col)
+	}
+	level, err := f.GetColOutlineLevel("test", "D")
+	require.NoError(t, err)
+	assert.Equal(t, uint8(1), level)
+	level, err = f.GetColOutlineLevel("test", "E")
+	require.NoError(t, err)
+	assert.Equal(t, uint8(0), level)
+
+	got, err := ReadRows[customer](f, "test", "B2")
+	require.NoError(t, err)
+	assert.Equal(t, customers, got)
+}
+
+func TestSheetWithStreamWriter_HiddenColumns(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	ssw, err := NewSheetWithStreamWriter[customer](f, "test", "A1", true)
+	require.NoError(t, err)
+
+	require.NoError(t, ssw.SetHeader())
+	for _, c := range customers {
+		require.NoError(t, ssw.SetRow(c))
+	}
+	require.NoError(t, ssw.Flush())
+
+	buf, err := f.WriteToBuffer()
+	require.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	rc, err := zr.Open("xl/worksheets/sheet2.xml")
+	require.NoError(t, err)
+	sheetXML, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Contains(t, string(sheetXML), `<col min="3" max="3" width="0" customWidth="1"/>`)
+	assert.Contains(t, string(sheetXML), `<col min="4" max="4" width="0" customWidth="1"/>`)
+
+	got, err := ReadRows[customer](f, "test", "A1")
+	require.NoError(t, err)
+	assert.Equal(t, customers, got)
+}
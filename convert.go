@@ -0,0 +1,143 @@
+package exceltable
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	durationType        = reflect.TypeFor[time.Duration]()
+	byteSliceType       = reflect.TypeFor[[]byte]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// timeLayouts are the layouts accepted when parsing time.Time values.
+var timeLayouts = []string{time.RFC3339Nano, time.DateTime, time.DateOnly}
+
+// formatValue converts the underlying value of field to its text representation.
+func formatValue(field reflect.Value) string {
+	v := getUnderlyingValue(field)
+	if isNilPointer(v) {
+		return "" // NOTE: Value methods of v, such as MarshalText, panic.
+	}
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case encoding.TextMarshaler:
+		b, err := v.MarshalText()
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	}
+
+	return fmt.Sprint(v)
+}
+
+// parseValue parses text s and stores the result in field.
+//
+// An empty s leaves pointer fields nil.
+func parseValue(s string, field reflect.Value) error {
+	if field.Kind() == reflect.Pointer {
+		if s == "" {
+			field.SetZero()
+			return nil
+		}
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return parseValue(s, field.Elem())
+	}
+
+	switch field.Type() {
+	case timeType:
+		if s == "" {
+			field.SetZero()
+			return nil
+		}
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				field.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		_, err := time.Parse(time.RFC3339Nano, s)
+		return err
+	case durationType:
+		if s == "" {
+			field.SetZero()
+			return nil
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	case byteSliceType:
+		field.SetBytes([]byte(s))
+		return nil
+	}
+
+	if field.CanAddr() && field.Addr().Type().Implements(textUnmarshalerType) {
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Bool:
+		if s == "" {
+			field.SetBool(false)
+			return nil
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if s == "" {
+			field.SetInt(0)
+			return nil
+		}
+		n, err := strconv.ParseInt(s, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if s == "" {
+			field.SetUint(0)
+			return nil
+		}
+		n, err := strconv.ParseUint(s, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		if s == "" {
+			field.SetFloat(0)
+			return nil
+		}
+		n, err := strconv.ParseFloat(s, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	default:
+		return ErrUnsupportedType
+	}
+	return nil
+}
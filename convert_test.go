@@ -0,0 +1,73 @@
+package exceltable
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_formatValue(t *testing.T) {
+	s := "text"
+	tests := []struct {
+		name string
+		arg  any
+		want string
+	}{
+		{name: "string", arg: "text", want: "text"},
+		{name: "int", arg: -12, want: "-12"},
+		{name: "pointer", arg: &s, want: "text"},
+		{name: "nil pointer", arg: (*string)(nil), want: ""},
+		{name: "time", arg: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), want: "2025-01-02T03:04:05Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatValue(reflect.ValueOf(tt.arg))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseValue(t *testing.T) {
+	var v struct {
+		S  string
+		I  int8
+		U  uint
+		F  float64
+		B  bool
+		P  *int
+		T  time.Time
+		D  time.Duration
+		BS []byte
+	}
+	rv := reflect.ValueOf(&v).Elem()
+
+	require.NoError(t, parseValue("text", rv.Field(0)))
+	require.NoError(t, parseValue("-12", rv.Field(1)))
+	require.NoError(t, parseValue("12", rv.Field(2)))
+	require.NoError(t, parseValue("1.5", rv.Field(3)))
+	require.NoError(t, parseValue("true", rv.Field(4)))
+	require.NoError(t, parseValue("7", rv.Field(5)))
+	require.NoError(t, parseValue("2025-01-02", rv.Field(6)))
+	require.NoError(t, parseValue("1h", rv.Field(7)))
+	require.NoError(t, parseValue("bytes", rv.Field(8)))
+
+	assert.Equal(t, "text", v.S)
+	assert.Equal(t, int8(-12), v.I)
+	assert.Equal(t, uint(12), v.U)
+	assert.Equal(t, 1.5, v.F)
+	assert.True(t, v.B)
+	require.NotNil(t, v.P)
+	assert.Equal(t, 7, *v.P)
+	assert.Equal(t, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), v.T)
+	assert.Equal(t, time.Hour, v.D)
+	assert.Equal(t, []byte("bytes"), v.BS)
+
+	require.NoError(t, parseValue("", rv.Field(5)))
+	assert.Nil(t, v.P)
+
+	assert.Error(t, parseValue("1000", rv.Field(1)))
+	assert.Equal(t, ErrUnsupportedType, parseValue("x", reflect.ValueOf(&struct{ C chan int }{}).Elem().Field(0)))
+}
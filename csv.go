@@ -0,0 +1,267 @@
+package exceltable
+
+import (
+	"encoding/csv"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// csvReportHeader is the header row of the rule report written by WithCSVReport.
+var csvReportHeader = []string{"row", "column", "rule", "predicate"}
+
+// CSVOption configures CSVWriter and CSVReader.
+type CSVOption func(*csvOptions)
+
+type csvOptions struct {
+	report io.Writer
+	comma  rune
+}
+
+// WithCSVReport writes a side-channel report of the cells matching rules to w, as CSV
+// with the columns "row", "column", "rule" and "predicate". Rows are numbered from 1,
+// excluding the header row.
+func WithCSVReport(w io.Writer) CSVOption {
+	return func(o *csvOptions) {
+		o.report = w
+	}
+}
+
+// WithCSVComma sets the field delimiter (',' by default).
+func WithCSVComma(comma rune) CSVOption {
+	return func(o *csvOptions) {
+		o.comma = comma
+	}
+}
+
+func newCSVOptions(opts []CSVOption) *csvOptions {
+	o := &csvOptions{comma: ','}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// csvReporter writes the cells matching rules as CSV.
+type csvReporter struct {
+	*schema
+	w *csv.Writer
+}
+
+func newCSVReporter(sc *schema, o *csvOptions) (*csvReporter, error) {
+	if o.report == nil {
+		return nil, nil
+	}
+
+	w := csv.NewWriter(o.report)
+	w.Comma = o.comma
+	if err := w.Write(csvReportHeader); err != nil {
+		return nil, err
+	}
+	return &csvReporter{sc, w}, nil
+}
+
+// report evaluates the rules of obj and writes the matching cells as row.
+func (r *csvReporter) report(row int, obj any) error {
+	if r == nil {
+		return nil
+	}
+
+	ptrV := reflect.ValueOf(obj)
+	v := ptrV.Elem()
+	for col, i := range r.fields {
+		rule, err := r.matchRule(col, ptrV, v.Field(i))
+		if err != nil {
+			return err
+		}
+		if rule == nil {
+			continue
+		}
+
+		record := []string{strconv.Itoa(row), r.header[col].(string), rule.tag, rule.key}
+		if err := r.w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *csvReporter) flush() error {
+	if r == nil {
+		return nil
+	}
+	r.w.Flush()
+	return r.w.Error()
+}
+
+// CSVWriter provides methods to write data of type M as CSV.
+type CSVWriter[M any] struct {
+	*schema
+	*csv.Writer
+	reporter *csvReporter
+	row      int // current number of rows
+}
+
+// NewCSVWriter creates a new exceltable.CSVWriter writing to w.
+//
+//	cw, _ := exceltable.NewCSVWriter[YourStruct](w)
+func NewCSVWriter[M any](w io.Writer, opts ...CSVOption) (*CSVWriter[M], error) {
+	sc, err := newDetachedSchema(reflect.TypeFor[M](), csvTag, excelTag) // NOTE: csv > excel > field name.
+	if err != nil {
+		return nil, err
+	}
+
+	o := newCSVOptions(opts)
+	reporter, err := newCSVReporter(sc, o)
+	if err != nil {
+		return nil, err
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = o.comma
+
+	return &CSVWriter[M]{
+		schema:   sc,
+		Writer:   cw,
+		reporter: reporter,
+		row:      1,
+	}, nil
+}
+
+// SetHeader writes the header row.
+//
+// It must be called before writing any data rows.
+func (cw *CSVWriter[M]) SetHeader() error {
+	record := make([]string, 0, cw.tableWidth)
+	for _, h := range cw.header {
+		record = append(record, h.(string))
+	}
+	return cw.Write(record)
+}
+
+// SetRow writes a row of data.
+func (cw *CSVWriter[M]) SetRow(obj *M) error {
+	v := reflect.ValueOf(obj).Elem()
+
+	record := make([]string, 0, cw.tableWidth)
+	for _, i := range cw.fields {
+		record = append(record, formatValue(v.Field(i)))
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+
+	if err := cw.reporter.report(cw.row, obj); err != nil {
+		return err
+	}
+
+	cw.row++
+	return nil
+}
+
+// Flush writes any buffered data (including the report) to the underlying io.Writer.
+func (cw *CSVWriter[M]) Flush() error {
+	cw.Writer.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	return cw.reporter.flush()
+}
+
+// CSVReader provides methods to read data of type M from CSV.
+//
+// CSV columns are mapped to fields of M by header, and unknown columns are ignored.
+type CSVReader[M any] struct {
+	*schema
+	*csv.Reader
+	reporter *csvReporter
+	cols     []int // schema column of each CSV column, or -1
+	row      int   // current number of rows
+}
+
+// NewCSVReader creates a new exceltable.CSVReader reading from r.
+// It reads the header row immediately.
+//
+//	cr, _ := exceltable.NewCSVReader[YourStruct](r)
+func NewCSVReader[M any](r io.Reader, opts ...CSVOption) (*CSVReader[M], error) {
+	sc, err := newDetachedSchema(reflect.TypeFor[M](), csvTag, excelTag) // NOTE: csv > excel > field name.
+	if err != nil {
+		return nil, err
+	}
+
+	o := newCSVOptions(opts)
+	reporter, err := newCSVReporter(sc, o)
+	if err != nil {
+		return nil, err
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma = o.comma
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	cols := make([]int, len(header))
+	for i, h := range header {
+		cols[i] = -1
+		if col, ok := sc.columnByHeader(h); ok {
+			cols[i] = col
+		}
+	}
+
+	return &CSVReader[M]{
+		schema:   sc,
+		Reader:   cr,
+		reporter: reporter,
+		cols:     cols,
+		row:      1,
+	}, nil
+}
+
+// Read reads a row of data. It returns io.EOF when there are no more rows.
+func (cr *CSVReader[M]) Read() (*M, error) {
+	record, err := cr.Reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			if err := cr.reporter.flush(); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+
+	obj := new(M)
+	v := reflect.ValueOf(obj).Elem()
+	for i, s := range record {
+		if i >= len(cr.cols) || cr.cols[i] < 0 {
+			continue
+		}
+		if err := parseValue(s, v.Field(cr.fields[cr.cols[i]])); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cr.reporter.report(cr.row, obj); err != nil {
+		return nil, err
+	}
+
+	cr.row++
+	return obj, nil
+}
+
+// ReadAll reads all the remaining rows of data.
+func (cr *CSVReader[M]) ReadAll() ([]*M, error) {
+	objs := make([]*M, 0)
+	for {
+		obj, err := cr.Read()
+		if err == io.EOF {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+}
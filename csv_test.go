@@ -0,0 +1,72 @@
+package exceltable
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVWriter(t *testing.T) {
+	var buf, report bytes.Buffer
+	cw, err := NewCSVWriter[person](&buf, WithCSVReport(&report))
+	require.NoError(t, err)
+
+	require.NoError(t, cw.SetHeader())
+	for _, p := range persons {
+		require.NoError(t, cw.SetRow(p))
+	}
+	require.NoError(t, cw.Flush())
+
+	want := "ID,name,age,address,account_number,SpecialID\n" +
+		"ID-123456,Alice,17,,0000-0000-0000-0000,\n" +
+		"ID-112358,Bob,32,Boston,1111-1111-1111-1111,\n" +
+		",Carol,100,京都,,SID-999999\n"
+	assert.Equal(t, want, buf.String())
+
+	wantReport := "row,column,rule,predicate\n" +
+		"1,name,newface,isNewFace\n" +
+		"1,age,warn,IsChild\n" +
+		"2,SpecialID,error,nil\n" +
+		"3,ID,error,zero\n" +
+		"3,age,warn,IsOld\n" +
+		"3,SpecialID,warn,notZero\n"
+	assert.Equal(t, wantReport, report.String())
+}
+
+func TestCSVReader(t *testing.T) {
+	var buf bytes.Buffer
+	cw, err := NewCSVWriter[person](&buf, WithCSVComma(';'))
+	require.NoError(t, err)
+	require.NoError(t, cw.SetHeader())
+	for _, p := range persons {
+		require.NoError(t, cw.SetRow(p))
+	}
+	require.NoError(t, cw.Flush())
+
+	var report bytes.Buffer
+	cr, err := NewCSVReader[person](&buf, WithCSVComma(';'), WithCSVReport(&report))
+	require.NoError(t, err)
+	got, err := cr.ReadAll()
+	require.NoError(t, err)
+
+	want := []*person{
+		{ID: "ID-123456", Name: "Alice", Age: 17, Address: "", AccountNumber: "0000-0000-0000-0000", SpecialID: nil},
+		{ID: "ID-112358", Name: "Bob", Age: 32, Address: "Boston", AccountNumber: "1111-1111-1111-1111", SpecialID: nil},
+		{ID: "", Name: "Carol", Age: 100, Address: "京都", AccountNumber: "", SpecialID: &specialIDs[2]},
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(person{})); diff != "" {
+		t.Errorf("CSVReader.ReadAll() mismatch (-want +got):\n%s", diff)
+	}
+	assert.Contains(t, report.String(), "3;ID;error;zero\n")
+}
+
+func TestCSVReader_Negative(t *testing.T) {
+	cr, err := NewCSVReader[person](strings.NewReader("age\nold\n"))
+	require.NoError(t, err)
+	_, err = cr.Read()
+	assert.Error(t, err)
+}
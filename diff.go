@@ -0,0 +1,254 @@
+package exceltable
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Header and values of the status column of diff sheets.
+const (
+	diffStatusHeader = "Status"
+	statusAdded      = "Added"
+	statusRemoved    = "Removed"
+	statusChanged    = "Changed"
+	statusUnchanged  = "Unchanged"
+)
+
+// Rule tags replacing the default styles of diff sheets if registered.
+const (
+	addedTag   ruleTagType = "added"
+	removedTag ruleTagType = "removed"
+	changedTag ruleTagType = "changed"
+)
+
+// diffStyles are the default styles of added rows, removed rows and changed cells of diff sheets.
+// They are not registered as rules, so that the legend and the summary sheet of other sheets do not list them.
+var diffStyles = map[ruleTagType]*excelize.Style{
+	addedTag: {
+		Fill: excelize.Fill{
+			Type:    "pattern",
+			Pattern: 1,
+			Color:   []string{"#aaffaa"}, // light green
+		},
+	},
+	removedTag: {
+		Fill: excelize.Fill{
+			Type:    "pattern",
+			Pattern: 1,
+			Color:   []string{"#ffaaaa"}, // light red
+		},
+		Font: &excelize.Font{Strike: true},
+	},
+	changedTag: {
+		Fill: excelize.Fill{
+			Type:    "pattern",
+			Pattern: 1,
+			Color:   []string{"#ffddaa"}, // light orange
+		},
+	},
+}
+
+// diffRow is a row of a diff sheet.
+type diffRow[M any] struct {
+	obj     *M     // object written
+	old     *M     // object before the change, or nil if added or removed
+	status  string // value of the status column
+	changed []int  // columns whose values are changed
+}
+
+// diffRows pairs the objects of before and after having the same value in the key column col.
+// The rows are in the order of after, followed by the rows removed in the order of before.
+// Objects with duplicate keys are paired in order of occurrence.
+func diffRows[M any](sc *schema, before, after []*M, col int) []*diffRow[M] {
+	keyOf := func(obj *M) string {
+		return formatValue(reflect.ValueOf(obj).Elem().Field(sc.fields[col]))
+	}
+
+	olds := make(map[string][]*M)
+	for _, obj := range before {
+		k := keyOf(obj)
+		olds[k] = append(olds[k], obj)
+	}
+
+	rows := make([]*diffRow[M], 0, len(after))
+	paired := make(map[*M]bool)
+	for _, obj := range after {
+		k := keyOf(obj)
+		if len(olds[k]) == 0 {
+			rows = append(rows, &diffRow[M]{obj: obj, status: statusAdded})
+			continue
+		}
+
+		old := olds[k][0]
+		olds[k] = olds[k][1:]
+		paired[old] = true
+		row := &diffRow[M]{obj: obj, old: old, status: statusUnchanged, changed: changedColumns(sc, old, obj)}
+		if len(row.changed) > 0 {
+			row.status = statusChanged
+		}
+		rows = append(rows, row)
+	}
+	for _, obj := range before {
+		if !paired[obj] {
+			rows = append(rows, &diffRow[M]{obj: obj, status: statusRemoved})
+		}
+	}
+	return rows
+}
+
+// changedColumns returns the columns whose formatted values differ between old and obj.
+// Formula columns are not compared.
+func changedColumns[M any](sc *schema, old, obj *M) []int {
+	v, w := reflect.ValueOf(old).Elem(), reflect.ValueOf(obj).Elem()
+	var cols []int
+	for col, i := range sc.fields {
+		if sc.formulas[col] == "" && formatValue(v.Field(i)) != formatValue(w.Field(i)) {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// diffStyleID returns the style ID of the rule with tag of the highest priority,
+// or of the default style of diff sheets if there is none.
+func (f *File) diffStyleID(tag ruleTagType) (int, error) {
+	for _, r := range f.rules {
+		if r.tag == tag {
+			return r.styleID, nil
+		}
+	}
+	return f.NewStyle(diffStyles[tag])
+}
+
+// WriteDiff adds a new sheet comparing before and after, whose objects are paired by the key column,
+// referred to by header value or field name:
+//
+//	_ = exceltable.WriteDiff(f, "Diff", lastMonth, thisMonth, "ID")
+//
+// The table starts at B1 with the status column "Status" in column A, whose values are
+// "Added", "Removed", "Changed" or "Unchanged". The rows are in the order of after,
+// followed by the rows removed in the order of before. Added rows are styled in light green,
+// removed rows in light red with strikethrough, and changed cells in light orange with comments showing
+// the old values. The styles can be replaced by registering rules with the tags "added", "removed" and "changed".
+// Their fills and fonts are overlaid on the styles of the cells, such as those of rules and links,
+// and the comments are appended to the rule comments of WithRuleComments.
+//
+// The options are applied to the table as with NewSheet.
+func WriteDiff[M any](f *File, name string, before, after []M, key string, opts ...SheetOption) error {
+	s, err := NewSheet[M](f, name, "B1", false, opts...)
+	if err != nil {
+		return err
+	}
+	col, ok := s.columnByHeader(key)
+	if !ok || s.formulas[col] != "" {
+		return fmt.Errorf("%w: %q", ErrUnknownColumn, key)
+	}
+	styleIDs := make(map[ruleTagType]int, len(diffStyles))
+	for tag := range diffStyles {
+		if styleIDs[tag], err = f.diffStyleID(tag); err != nil {
+			return err
+		}
+	}
+
+	statusCell := func(row int) string {
+		cell, _ := excelize.CoordinatesToCellName(s.x-1, s.y+row)
+		return cell
+	}
+	if err := f.SetCellValue(name, statusCell(0), diffStatusHeader); err != nil {
+		return err
+	}
+	if err := s.SetHeader(); err != nil {
+		return err
+	}
+
+	for _, r := range diffRows(s.schema, pointers(before), pointers(after), col) {
+		row := s.row
+		if err := s.SetRow(r.obj); err != nil {
+			return err
+		}
+		if err := f.SetCellValue(name, statusCell(row), r.status); err != nil {
+			return err
+		}
+
+		switch r.status {
+		case statusAdded, statusRemoved:
+			tag := addedTag
+			if r.status == statusRemoved {
+				tag = removedTag
+			}
+			if err := s.overlayCellStyle(statusCell(row), styleIDs[tag]); err != nil {
+				return err
+			}
+			for col := range s.tableWidth {
+				if err := s.overlayCellStyle(s.coordinatesToCellName(col, row), styleIDs[tag]); err != nil {
+					return err
+				}
+			}
+		case statusChanged:
+			if err := s.markChanges(r, row, statusCell(row), styleIDs[changedTag]); err != nil {
+				return err
+			}
+		}
+	}
+	return s.Flush()
+}
+
+// markChanges styles the status cell and the changed cells of r written in row by styleID,
+// and comments the old values on the changed cells, appended to the rule comments if any.
+func (s *Sheet[M]) markChanges(r *diffRow[M], row int, statusCell string, styleID int) error {
+	if err := s.overlayCellStyle(statusCell, styleID); err != nil {
+		return err
+	}
+
+	comments, err := s.File.GetComments(s.name)
+	if err != nil {
+		return err
+	}
+	old := reflect.ValueOf(r.old).Elem()
+	for _, col := range r.changed {
+		cell := s.coordinatesToCellName(col, row)
+		if err := s.overlayCellStyle(cell, styleID); err != nil {
+			return err
+		}
+
+		text := fmt.Sprintf("Before: %q", formatValue(old.Field(s.fields[col])))
+		if i := slices.IndexFunc(comments, func(c excelize.Comment) bool { return c.Cell == cell }); i != -1 {
+			text = comments[i].Text + "\n" + text
+			if err := s.File.DeleteComment(s.name, cell); err != nil {
+				return err
+			}
+		}
+		if err := s.File.AddComment(s.name, excelize.Comment{
+			Author: commentAuthor,
+			Cell:   cell,
+			Text:   text,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// overlayCellStyle overlays the style overlay on the style of cell, keeping the rule, link and unlocked styles.
+func (s *Sheet[M]) overlayCellStyle(cell string, overlay int) error {
+	styleID, err := s.File.GetCellStyle(s.name, cell)
+	if err != nil {
+		return err
+	}
+	if styleID, err = s.File.overlayStyle(styleID, overlay); err != nil {
+		return err
+	}
+	return s.File.SetCellStyle(s.name, cell, cell, styleID)
+}
+
+// pointers returns the pointers to the elements of objs.
+func pointers[M any](objs []M) []*M {
+	ptrs := make([]*M, len(objs))
+	for i := range objs {
+		ptrs[i] = &objs[i]
+	}
+	return ptrs
+}
@@ -0,0 +1,177 @@
+package exceltable
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+type stock struct {
+	Code string `excel:"Code"`
+	Name string `excel:"Name"`
+	Qty  int    `excel:"Qty"`
+}
+
+var (
+	stocksBefore = []stock{
+		{Code: "A", Name: "Apple", Qty: 10},
+		{Code: "B", Name: "Banana", Qty: 20},
+		{Code: "C", Name: "Cherry", Qty: 30},
+	}
+	stocksAfter = []stock{
+		{Code: "A", Name: "Apple", Qty: 10},
+		{Code: "C", Name: "Cherry", Qty: 25},
+		{Code: "D", Name: "Durian", Qty: 5},
+	}
+)
+
+func Test_diffRows(t *testing.T) {
+	sc, err := newDetachedSchema(reflect.TypeFor[stock](), excelTag)
+	require.NoError(t, err)
+
+	before, after := pointers(stocksBefore), pointers(stocksAfter)
+	rows := diffRows(sc, before, after, 0)
+	require.Len(t, rows, 4)
+	assert.Equal(t, statusUnchanged, rows[0].status)
+	assert.Equal(t, statusChanged, rows[1].status)
+	assert.Equal(t, []int{2}, rows[1].changed)
+	assert.Same(t, before[2], rows[1].old)
+	assert.Equal(t, statusAdded, rows[2].status)
+	assert.Same(t, after[2], rows[2].obj)
+	assert.Equal(t, statusRemoved, rows[3].status)
+	assert.Same(t, before[1], rows[3].obj)
+}
+
+func TestWriteDiff(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	require.NoError(t, WriteDiff(f, "Diff", stocksBefore, stocksAfter, "Code"))
+
+	rows, err := f.GetRows("Diff")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Status", "Code", "Name", "Qty"},
+		{"Unchanged", "A", "Apple", "10"},
+		{"Changed", "C", "Cherry", "25"},
+		{"Added", "D", "Durian", "5"},
+		{"Removed", "B", "Banana", "20"},
+	}, rows)
+
+	styleOf := func(cell string) int {
+		styleID, err := f.GetCellStyle("Diff", cell)
+		require.NoError(t, err)
+		return styleID
+	}
+	fillOf := func(cell string) []string {
+		style, err := f.GetStyle(styleOf(cell))
+		require.NoError(t, err)
+		return style.Fill.Color
+	}
+	assert.Zero(t, styleOf("D2"))
+	assert.Equal(t, []string{"FFDDAA"}, fillOf("A3"))
+	assert.Equal(t, []string{"FFDDAA"}, fillOf("D3"))
+	assert.Zero(t, styleOf("C3"))
+	assert.Equal(t, []string{"AAFFAA"}, fillOf("A4"))
+	assert.Equal(t, []string{"AAFFAA"}, fillOf("D4"))
+	assert.Equal(t, []string{"FFAAAA"}, fillOf("C5"))
+
+	style, err := f.GetStyle(styleOf("B5"))
+	require.NoError(t, err)
+	assert.True(t, style.Font.Strike)
+	for _, r := range f.rules {
+		assert.NotContains(t, []ruleTagType{addedTag, removedTag, changedTag}, r.tag)
+	}
+
+	comments, err := f.GetComments("Diff")
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "D3", comments[0].Cell)
+	assert.Equal(t, `Before: "30"`, comments[0].Text)
+}
+
+func TestWriteDiff_UnknownColumn(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	assert.ErrorIs(t, WriteDiff(f, "Diff", stocksBefore, stocksAfter, "Unknown"), ErrUnknownColumn)
+}
+
+func TestWriteDiff_Rules(t *testing.T) {
+	saved := slices.Clone(rules.v)
+	t.Cleanup(func() {
+		rules.Lock()
+		defer rules.Unlock()
+		rules.v = saved
+	})
+	DeleteAllRules() // NOTE: Diff sheets are styled without rules.
+	RegisterRule(0, addedTag, &excelize.Style{Font: &excelize.Font{Bold: true}})
+
+	f, err := NewFile()
+	require.NoError(t, err)
+	require.NoError(t, WriteDiff(f, "Diff", stocksBefore, stocksAfter, "Code"))
+
+	styleOf := func(cell string) *excelize.Style {
+		styleID, err := f.GetCellStyle("Diff", cell)
+		require.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		require.NoError(t, err)
+		return style
+	}
+	assert.Equal(t, []string{"FFDDAA"}, styleOf("A3").Fill.Color)
+	assert.True(t, styleOf("A4").Font.Bold)
+	assert.Empty(t, styleOf("A4").Fill.Color)
+	assert.Equal(t, []string{"FFAAAA"}, styleOf("A5").Fill.Color)
+	assert.True(t, styleOf("A5").Font.Strike)
+}
+
+type auditedStock struct {
+	Code string `excel:"Code"`
+	Qty  int    `excel:"Qty" warn:"zero"`
+	Site string `excel:"Site" link:"url"`
+	Note string `excel:"Note,editable"`
+}
+
+func TestWriteDiff_CellStyles(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	before := []auditedStock{{Code: "A", Qty: 5}}
+	after := []auditedStock{{Code: "A", Qty: 0, Site: "https://example.com/a"}, {Code: "B", Qty: 0, Site: "https://example.com/b"}}
+	require.NoError(t, WriteDiff(f, "Diff", before, after, "Code",
+		WithRuleComments(), WithSheetProtection(&excelize.SheetProtectionOptions{})))
+
+	styleOf := func(cell string) *excelize.Style {
+		styleID, err := f.GetCellStyle("Diff", cell)
+		require.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		require.NoError(t, err)
+		return style
+	}
+	for _, row := range []string{"2", "3"} {
+		fill := []string{"FFDDAA"}
+		if row == "3" {
+			fill = []string{"AAFFAA"}
+		}
+		site := styleOf("D" + row)
+		assert.Equal(t, fill, site.Fill.Color, row)
+		assert.Equal(t, "single", site.Font.Underline, row)
+		assert.Equal(t, hyperlinkFontColor, site.Font.Color, row)
+
+		note := styleOf("E" + row)
+		require.NotNil(t, note.Protection, row)
+		assert.False(t, note.Protection.Locked, row)
+	}
+	assert.Equal(t, []string{"AAFFAA"}, styleOf("C3").Fill.Color)
+
+	comments, err := f.GetComments("Diff")
+	require.NoError(t, err)
+	texts := make(map[string]string)
+	for _, c := range comments {
+		texts[c.Cell] = c.Text
+	}
+	assert.Equal(t, "warn: zero\nBefore: \"5\"", texts["C2"])
+	assert.Equal(t, `Before: ""`, texts["D2"])
+	assert.Equal(t, "warn: zero", texts["C3"])
+}
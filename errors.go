@@ -4,7 +4,19 @@ import "errors"
 
 // Sentinel errors.
 var (
-	ErrNotStructType    = errors.New("exceltable: not struct type")
-	ErrUnknownPredicate = errors.New("exceltable: unknown predicate method")
-	ErrInvalidPredicate = errors.New("exceltable: invalid predicate method")
+	ErrNotStructType     = errors.New("exceltable: not struct type")
+	ErrUnknownPredicate  = errors.New("exceltable: unknown predicate method")
+	ErrInvalidPredicate  = errors.New("exceltable: invalid predicate method")
+	ErrTableNotFound     = errors.New("exceltable: table not found")
+	ErrUnsupportedType   = errors.New("exceltable: unsupported field type")
+	ErrInvalidValidation = errors.New("exceltable: invalid data validation tag")
+	ErrUnknownColumn     = errors.New("exceltable: unknown column")
+	ErrSheetExists       = errors.New("exceltable: sheet already exists")
+	ErrTableExists       = errors.New("exceltable: table already exists")
+	ErrInvalidTableName  = errors.New("exceltable: invalid table name")
+	ErrTableOverlap      = errors.New("exceltable: table overlaps another table")
+	ErrStreamLayout      = errors.New("exceltable: unsupported layout for stream writer")
+	ErrInvalidImage      = errors.New("exceltable: invalid image")
+	ErrMergedCells       = errors.New("exceltable: merged cells in table")
+	ErrLinkTarget        = errors.New("exceltable: sheet linked is not flushed")
 )
@@ -10,21 +10,34 @@ package exceltable
 
 import (
 	"io"
-	"slices"
+	"sync"
 
 	"github.com/xuri/excelize/v2"
 )
 
 // fileRule represents relation between rule tag and style ID.
 type fileRule struct {
-	tag     ruleTagType
-	styleID int
+	tag      ruleTagType
+	priority int
+	styleID  int
 }
 
 // File wraps excelize.File and holds style rules.
 type File struct {
 	*excelize.File
 	rules []*fileRule // NOTE: Rules are stored in descending order of priority.
+	usage *ruleUsage  // predicates used by columns in this workbook
+
+	mu         sync.Mutex
+	stats      []*sheetStats                // rule hits of sheets in this workbook
+	unlocked   map[int]int                  // unlocked variant of each style ID
+	concealed  map[int]int                  // variant of each style ID hiding the value
+	tableNames map[string]string            // sheet name by table name reserved, in lower case
+	hyperlinks map[int]int                  // hyperlink variant of each style ID
+	keys       map[string]map[string]string // cell by value of the key column of each sheet name, in lower case
+	flushed    map[string]bool              // sheet names flushed, whose keys are all registered, in lower case
+	links      map[string][]pendingLink     // links waiting for the sheet linked to be flushed, by sheet name in lower case
+	overlays   map[[2]int]int               // variant of each style ID overlaid with each style ID
 }
 
 // NewFile creates a new exceltable.File and returns its pointer.
@@ -66,20 +79,88 @@ func Wrap(file *excelize.File) (*File, error) {
 	return &File{
 		File:  file,
 		rules: rules,
+		usage: newRuleUsage(),
 	}, nil
 }
 
-func createFileRules(file *excelize.File) ([]*fileRule, error) {
-	rules.Lock()
-	defer rules.Unlock()
+// addStats registers the counters of a sheet for the summary sheet.
+func (f *File) addStats(st *sheetStats) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.stats = append(f.stats, st)
+}
+
+// overlayStyle returns the ID of the style which is the same as styleID but with the fill,
+// the font emphasis and the font color of the style overlay, such as a rule style.
+// The style is created at the first call and reused afterwards.
+func (f *File) overlayStyle(styleID, overlay int) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if styleID == 0 {
+		return overlay, nil
+	}
+	key := [2]int{styleID, overlay}
+	if id, ok := f.overlays[key]; ok {
+		return id, nil
+	}
+
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		return 0, err
+	}
+	o, err := f.GetStyle(overlay)
+	if err != nil {
+		return 0, err
+	}
+	if o.Fill.Pattern != 0 || len(o.Fill.Color) > 0 { // NOTE: GetStyle returns an empty pattern fill for no fill.
+		style.Fill = o.Fill
+	}
+	style.Font = overlayFont(style.Font, o.Font)
 
-	fileRules := make([]*fileRule, 0, len(rules.v))
-	for _, r := range slices.Backward(rules.v) { // NOTE: Rules are sorted in ascending order of priority.
+	id, err := f.NewStyle(style)
+	if err != nil {
+		return 0, err
+	}
+	if f.overlays == nil {
+		f.overlays = make(map[[2]int]int)
+	}
+	f.overlays[key] = id
+	return id, nil
+}
+
+// overlayFont returns font with the emphasis and the color of overlay.
+// The family and the size of overlay are ignored, since GetStyle returns the default font for no font.
+func overlayFont(font, overlay *excelize.Font) *excelize.Font {
+	if overlay == nil {
+		return font
+	}
+	merged := excelize.Font{}
+	if font != nil {
+		merged = *font
+	}
+	merged.Bold = merged.Bold || overlay.Bold
+	merged.Italic = merged.Italic || overlay.Italic
+	merged.Strike = merged.Strike || overlay.Strike
+	if overlay.Underline != "" {
+		merged.Underline = overlay.Underline
+	}
+	if overlay.Color != "" {
+		merged.Color, merged.ColorTheme, merged.ColorIndexed, merged.ColorTint = overlay.Color, nil, 0, 0
+	}
+	return &merged
+}
+
+func createFileRules(file *excelize.File) ([]*fileRule, error) {
+	rs := snapshotRules()
+	fileRules := make([]*fileRule, 0, len(rs))
+	for _, r := range rs {
 		styleID, err := file.NewStyle(r.style)
 		if err != nil {
 			return nil, err
 		}
-		fileRules = append(fileRules, &fileRule{r.tag, styleID})
+		fileRules = append(fileRules, &fileRule{r.tag, r.priority, styleID})
 	}
 
 	return fileRules, nil
@@ -0,0 +1,103 @@
+package exceltable
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// excelFormulaTag is the tag declaring a formula column on a marker field, e.g.
+//
+//	_ struct{} `excel:"Total" excelformula:"=[@Qty]*[@Price]"`
+const excelFormulaTag string = "excelformula"
+
+// formulaRefPattern matches the column references in formulas:
+// structured references "[@Header]" and "[@[Header]]", and A1 references "{@Header}".
+// NOTE: "@" distinguishes A1 references from array constants, such as "{1,2,3}".
+var formulaRefPattern = regexp.MustCompile(`\[@\[([^\]]+)\]\]|\[@([^\[\]]+)\]|\{@([^{}]+)\}`)
+
+// structuredRefEscaper escapes the special characters of column names in structured references.
+var structuredRefEscaper = strings.NewReplacer("'", "''", "[", "'[", "]", "']", "#", "'#")
+
+// addFormulaColumns inserts the formula columns declared by marker fields with the excelformula tag,
+// in the order of struct fields. Marker fields may be unexported, such as blank fields.
+func (s *schema) addFormulaColumns() {
+	fields := make([]int, 0, s.numField)
+	header := make([]any, 0, s.numField)
+	rulesList := make([][]*sheetRule, 0, s.numField)
+	formulas := make([]string, 0, s.numField)
+
+	col := 0
+	for i := range s.numField {
+		field := s.typ.Field(i)
+		if formula, ok := field.Tag.Lookup(excelFormulaTag); ok {
+			h, ok := lookupHeader(field, excelTag)
+			if !ok {
+				continue
+			}
+			s.skip[i] = false
+			fields = append(fields, i)
+			header = append(header, h)
+			rulesList = append(rulesList, []*sheetRule{})
+			formulas = append(formulas, strings.TrimPrefix(formula, "="))
+			continue
+		}
+
+		if col < s.tableWidth && s.fields[col] == i {
+			fields = append(fields, i)
+			header = append(header, s.header[col])
+			rulesList = append(rulesList, s.rulesList[col])
+			formulas = append(formulas, s.formulas[col])
+			col++
+		}
+	}
+
+	s.tableWidth = len(fields)
+	s.fields = fields
+	s.header = header
+	s.rulesList = rulesList
+	s.formulas = formulas
+}
+
+// formula returns the formula of column col in the given row, with the column references resolved:
+// "[@Header]" into the structured reference to the same row of the table, such as
+// "Table[[#This Row],[Header]]", and "{@Header}" into the cell of the same row, such as "C2".
+//
+// It returns "" for value columns.
+func (s *sheetBase[M]) formula(col, row int) (string, error) {
+	var err error
+	formula := formulaRefPattern.ReplaceAllStringFunc(s.formulas[col], func(ref string) string {
+		m := formulaRefPattern.FindStringSubmatch(ref)
+		name := m[1] + m[2] + m[3]
+		c, ok := s.columnByHeader(name)
+		if !ok {
+			err = fmt.Errorf("%w: %q", ErrUnknownColumn, name)
+			return ref
+		}
+
+		if m[3] != "" {
+			return s.coordinatesToCellName(c, row)
+		}
+		return fmt.Sprintf("%s[[#This Row],[%s]]", s.tableName(), structuredRefEscaper.Replace(s.header[c].(string)))
+	})
+	if err != nil {
+		return "", err
+	}
+	return formula, nil
+}
+
+// checkTableRefs returns ErrTableNotFound if a formula column has structured references
+// but no table is added, since they refer to the table.
+func (s *sheetBase[M]) checkTableRefs() error {
+	if s.hasTable {
+		return nil
+	}
+	for col, formula := range s.formulas {
+		for _, m := range formulaRefPattern.FindAllStringSubmatch(formula, -1) {
+			if m[3] == "" {
+				return fmt.Errorf("%w: %q has structured references, which require AddTable before Flush", ErrTableNotFound, s.header[col])
+			}
+		}
+	}
+	return nil
+}
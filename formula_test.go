@@ -0,0 +1,148 @@
+package exceltable
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderLine struct {
+	Item  string   `excel:"Item"`
+	Qty   int      `excel:"Qty"`
+	Price float64  `excel:"Unit Price"`
+	_     struct{} `excel:"Total" excelformula:"=[@Qty]*[@[Unit Price]]"`
+	_     struct{} `excel:"Tax" excelformula:"=ROUND({@Total}*0.1,0)"`
+	Note  string   `excel:"Note"`
+}
+
+var orderLines = []*orderLine{
+	{Item: "Pen", Qty: 3, Price: 120},
+	{Item: "Notebook", Qty: 2, Price: 250, Note: "A5"},
+}
+
+func Test_schema_addFormulaColumns(t *testing.T) {
+	sc, err := newDetachedSchema(reflect.TypeFor[orderLine](), excelTag)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"Item", "Qty", "Unit Price", "Note"}, sc.header)
+
+	sc.addFormulaColumns()
+	assert.Equal(t, 6, sc.tableWidth)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, sc.fields)
+	assert.Equal(t, []any{"Item", "Qty", "Unit Price", "Total", "Tax", "Note"}, sc.header)
+	assert.Equal(t, []string{"", "", "", "[@Qty]*[@[Unit Price]]", "ROUND({@Total}*0.1,0)", ""}, sc.formulas)
+	assert.Len(t, sc.rulesList, 6)
+}
+
+func TestSheet_FormulaColumns(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[orderLine](f, "Orders", "B2", true)
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetHeader())
+	for _, line := range orderLines {
+		require.NoError(t, s.SetRow(line))
+	}
+	require.NoError(t, s.AddDefaultTable())
+	require.NoError(t, s.Flush())
+
+	header, err := f.GetRows("Orders")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "Item", "Qty", "Unit Price", "Total", "Tax", "Note"}, header[1])
+
+	formula, err := f.GetCellFormula("Orders", "E4")
+	require.NoError(t, err)
+	assert.Equal(t, "OrdersTable[[#This Row],[Qty]]*OrdersTable[[#This Row],[Unit Price]]", formula)
+	formula, err = f.GetCellFormula("Orders", "F4")
+	require.NoError(t, err)
+	assert.Equal(t, "ROUND(E4*0.1,0)", formula)
+	note, err := f.GetCellValue("Orders", "G4")
+	require.NoError(t, err)
+	assert.Equal(t, "A5", note)
+}
+
+func TestSheetWithStreamWriter_FormulaColumns(t *testing.T) {
+	type line struct {
+		Qty   int      `excel:"Qty"`
+		Price float64  `excel:"Price"`
+		_     struct{} `excel:"Total" excelformula:"{@Qty}*{@Price}"`
+	}
+
+	f, err := NewFile()
+	require.NoError(t, err)
+	ssw, err := NewSheetWithStreamWriter[line](f, "test", "A1", true)
+	require.NoError(t, err)
+
+	require.NoError(t, ssw.SetHeader())
+	require.NoError(t, ssw.SetRow(&line{Qty: 3, Price: 120}))
+	require.NoError(t, ssw.Flush())
+
+	formula, err := f.GetCellFormula("test", "C2")
+	require.NoError(t, err)
+	assert.Equal(t, "A2*B2", formula)
+	total, err := f.CalcCellValue("test", "C2")
+	require.NoError(t, err)
+	assert.Equal(t, "360", total)
+}
+
+func TestSheet_FormulaArrayConstants(t *testing.T) {
+	type line struct {
+		Qty int      `excel:"Qty"`
+		_   struct{} `excel:"Sum" excelformula:"=SUM({1,2,3})*{@Qty}"`
+		_   struct{} `excel:"Label" excelformula:"=INDEX({\"a\",\"b\"},1)"`
+	}
+
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[line](f, "Lines", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	require.NoError(t, s.SetRow(&line{Qty: 2}))
+	require.NoError(t, s.Flush())
+
+	formula, err := f.GetCellFormula("Lines", "B2")
+	require.NoError(t, err)
+	assert.Equal(t, "SUM({1,2,3})*A2", formula)
+	formula, err = f.GetCellFormula("Lines", "C2")
+	require.NoError(t, err)
+	assert.Equal(t, `INDEX({"a","b"},1)`, formula)
+}
+
+func TestSheet_FormulaWithoutTable(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[orderLine](f, "Orders", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	require.NoError(t, s.SetRow(orderLines[0]))
+
+	assert.ErrorIs(t, s.Flush(), ErrTableNotFound)
+	require.NoError(t, s.AddDefaultTable())
+	assert.NoError(t, s.Flush())
+}
+
+func TestNewSheet_UnknownFormulaColumn(t *testing.T) {
+	type invalid struct {
+		Qty int      `excel:"Qty"`
+		_   struct{} `excel:"Total" excelformula:"=[@Qty]*[@Price]"`
+	}
+
+	f, err := NewFile()
+	require.NoError(t, err)
+	_, err = NewSheet[invalid](f, "test", "A1", true)
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+	assert.ErrorContains(t, err, `"Price"`)
+}
+
+func TestCSVWriter_FormulaColumns(t *testing.T) {
+	var buf bytes.Buffer
+	cw, err := NewCSVWriter[orderLine](&buf)
+	require.NoError(t, err)
+	require.NoError(t, cw.SetHeader())
+	require.NoError(t, cw.SetRow(orderLines[1]))
+	require.NoError(t, cw.Flush())
+	assert.Equal(t, "Item,Qty,Unit Price,Note\nNotebook,2,250,A5\n", buf.String())
+}
@@ -0,0 +1,251 @@
+package exceltable
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// defaultGroupLabel is the format of the key cell of subtotal rows unless specified by WithGroupLabel.
+const defaultGroupLabel = "%v Total"
+
+// GroupOption configures SetGroupedRows.
+type GroupOption func(*groupOptions)
+
+type groupOptions struct {
+	sort      bool
+	mergeKeys bool
+	label     string
+}
+
+func newGroupOptions(opts []GroupOption) *groupOptions {
+	o := &groupOptions{label: defaultGroupLabel}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithSortByKey sorts rows by the key stably before grouping them.
+// Without this option, rows must be sorted by the key, since only consecutive rows are grouped.
+func WithSortByKey() GroupOption {
+	return func(o *groupOptions) {
+		o.sort = true
+	}
+}
+
+// WithMergedKeys merges the key cells of the rows in each group.
+// Since tables cannot contain merged cells, AddTable returns ErrMergedCells once key cells are merged.
+func WithMergedKeys() GroupOption {
+	return func(o *groupOptions) {
+		o.mergeKeys = true
+	}
+}
+
+// WithGroupLabel sets the format of the key cell of subtotal rows, which is given the key value.
+// The default is "%v Total".
+func WithGroupLabel(format string) GroupOption {
+	return func(o *groupOptions) {
+		o.label = format
+	}
+}
+
+// groupWriter writes the rows of groups to a sheet.
+type groupWriter[M any] interface {
+	cellMerger
+	// setGroupRow writes a row in a group with outline level 1.
+	setGroupRow(obj *M) error
+	// setSubtotalRow writes a subtotal row.
+	setSubtotalRow(cells []*excelize.Cell) error
+}
+
+// setGroupedRows writes objs grouped by the key column by w.
+func (s *sheetBase[M]) setGroupedRows(w groupWriter[M], objs []*M, key string, opts []GroupOption) error {
+	o := newGroupOptions(opts)
+	col, ok := s.columnByHeader(key)
+	if !ok || s.formulas[col] != "" {
+		return fmt.Errorf("%w: %q", ErrUnknownColumn, key)
+	}
+	if s.sealed {
+		return ErrTableOverlap
+	}
+	keyOf := func(obj *M) reflect.Value {
+		return reflect.ValueOf(obj).Elem().Field(s.fields[col])
+	}
+
+	if o.sort {
+		objs = slices.Clone(objs)
+		slices.SortStableFunc(objs, func(a, b *M) int {
+			return compareValues(getUnderlyingValue(keyOf(a)), getUnderlyingValue(keyOf(b)))
+		})
+	}
+
+	for start := 0; start < len(objs); {
+		k := formatValue(keyOf(objs[start]))
+		end := start + 1
+		for end < len(objs) && formatValue(keyOf(objs[end])) == k {
+			end++
+		}
+
+		top := s.row
+		for _, obj := range objs[start:end] {
+			if err := w.setGroupRow(obj); err != nil {
+				return err
+			}
+		}
+		s.endRuns() // NOTE: Subtotal rows end the runs of the "mergeRepeats" option.
+		bottom := s.row - 1
+		if o.mergeKeys && s.repeats[col] == nil && bottom > top {
+			if err := w.mergeCells(col, top, bottom); err != nil {
+				return err
+			}
+			s.mergedKeys = true
+		}
+
+		label := fmt.Sprintf(o.label, k)
+		if err := w.setSubtotalRow(s.subtotalRow(col, label, top, bottom)); err != nil {
+			return err
+		}
+		s.observeWidth(col, label)
+		s.row++
+		start = end
+	}
+	return nil
+}
+
+// subtotalRow returns the cells of the subtotal row of the group from row top to bottom:
+// label in the key column, and SUBTOTAL formulas in the columns with the total tag of aggregation functions.
+func (s *sheetBase[M]) subtotalRow(keyCol int, label string, top, bottom int) []*excelize.Cell {
+	cells := make([]*excelize.Cell, s.tableWidth)
+	for col := range cells {
+		cells[col] = &excelize.Cell{}
+		if col == keyCol {
+			cells[col].Value = label
+			continue
+		}
+		if s.totals == nil {
+			continue
+		}
+		if n, ok := subtotalFunctions[s.totals[col]]; ok {
+			cells[col].Formula = fmt.Sprintf("SUBTOTAL(%d,%s:%s)", n, s.coordinatesToCellName(col, top), s.coordinatesToCellName(col, bottom))
+		}
+	}
+	return cells
+}
+
+// compareValues compares the underlying values of fields, placing nil and nil pointers first.
+// Values of different or unordered types are compared as strings.
+func compareValues(a, b any) int {
+	if isNilPointer(a) {
+		a = nil
+	}
+	if isNilPointer(b) {
+		b = nil
+	}
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	if t, ok := a.(time.Time); ok {
+		if u, ok := b.(time.Time); ok {
+			return t.Compare(u)
+		}
+	}
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == vb.Kind() {
+		switch va.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return cmp.Compare(va.Int(), vb.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return cmp.Compare(va.Uint(), vb.Uint())
+		case reflect.Float32, reflect.Float64:
+			return cmp.Compare(va.Float(), vb.Float())
+		case reflect.String:
+			return strings.Compare(va.String(), vb.String())
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// SetGroupedRows writes objs grouped by the key column, referred to by header value or field name.
+// The rows of each group are outlined with level 1, and followed by a subtotal row with the label
+// "<key> Total" and SUBTOTAL formulas of the columns with the total tag of aggregation functions:
+//
+//	type Employee struct {
+//		Department string `excel:"Department"`
+//		Salary     int    `excel:"Salary" total:"sum"`
+//	}
+//
+//	_ = s.SetGroupedRows(employees, "Department", exceltable.WithSortByKey(), exceltable.WithMergedKeys())
+//
+// Subtotal rows are ignored by the SUBTOTAL formulas of the totals row.
+// With WithMergedKeys, the key cells other than the top one of each group are cleared by excelize.File.MergeCell,
+// whereas SheetWithStreamWriter keeps their values. ReadRows reads them as the value of the top one for both.
+func (s *Sheet[M]) SetGroupedRows(objs []*M, key string, opts ...GroupOption) error {
+	return s.setGroupedRows(s, objs, key, opts)
+}
+
+func (s *Sheet[M]) setGroupRow(obj *M) error {
+	if err := s.SetRow(obj); err != nil {
+		return err
+	}
+	return s.File.SetRowOutlineLevel(s.name, s.y+s.row-1, 1)
+}
+
+func (s *Sheet[M]) setSubtotalRow(cells []*excelize.Cell) error {
+	for col, cell := range cells {
+		cellName := s.coordinatesToCellName(col, s.row)
+		if cell.Formula != "" {
+			if err := s.File.File.SetCellFormula(s.name, cellName, cell.Formula); err != nil {
+				return err
+			}
+		} else if cell.Value != nil {
+			if err := s.setCellValue(col, s.row, cell.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Sheet[M]) mergeCells(col, top, bottom int) error {
+	return s.File.MergeCell(s.name, s.coordinatesToCellName(col, top), s.coordinatesToCellName(col, bottom))
+}
+
+// SetGroupedRows writes objs grouped by the key column, referred to by header value or field name.
+// See Sheet.SetGroupedRows for details.
+func (ssw *SheetWithStreamWriter[M]) SetGroupedRows(objs []*M, key string, opts ...GroupOption) error {
+	return ssw.setGroupedRows(ssw, objs, key, opts)
+}
+
+func (ssw *SheetWithStreamWriter[M]) setGroupRow(obj *M) error {
+	return ssw.setDataRow(obj, excelize.RowOpts{OutlineLevel: 1})
+}
+
+func (ssw *SheetWithStreamWriter[M]) setSubtotalRow(cells []*excelize.Cell) error {
+	values := make([]any, 0, len(cells))
+	for _, cell := range cells {
+		values = append(values, cell)
+	}
+	return ssw.setRow(ssw.coordinatesToCellName(0, ssw.row), values)
+}
+
+func (ssw *SheetWithStreamWriter[M]) mergeCells(col, top, bottom int) error {
+	return ssw.StreamWriter.MergeCell(ssw.coordinatesToCellName(col, top), ssw.coordinatesToCellName(col, bottom))
+}
+
+// isNilPointer reports whether v is a nil pointer.
+func isNilPointer(v any) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
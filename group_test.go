@@ -0,0 +1,192 @@
+package exceltable
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+type employee struct {
+	Department string `excel:"部署"`
+	Name       string `excel:"氏名"`
+	Salary     int    `excel:"給与" total:"sum"`
+}
+
+var employees = []*employee{
+	{"Sales", "Alice", 300},
+	{"Dev", "Bob", 500},
+	{"Sales", "Carol", 200},
+	{"Dev", "Dave", 400},
+	{"HR", "Eve", 100},
+}
+
+func Test_compareValues(t *testing.T) {
+	tests := []struct {
+		a, b any
+		want int
+	}{
+		{9, 10, -1},
+		{uint(2), uint(1), 1},
+		{1.5, 1.5, 0},
+		{"a", "b", -1},
+		{nil, "a", -1},
+		{"a", nil, 1},
+		{nil, nil, 0},
+		{(*string)(nil), "a", -1}, // nil pointers are placed first.
+		{10, "9", -1},             // compared as strings.
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, compareValues(tt.a, tt.b), "%v, %v", tt.a, tt.b)
+	}
+}
+
+func TestSheet_SetGroupedRows(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	s, err := NewSheet[employee](f, "Employees", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	require.NoError(t, s.SetGroupedRows(employees, "Department", WithSortByKey(), WithGroupLabel("%v 計")))
+	require.NoError(t, s.AddDefaultTable())
+	require.NoError(t, s.Flush())
+
+	rows, err := f.GetRows("Employees", excelize.Options{RawCellValue: true})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"部署", "氏名", "給与"},
+		{"Dev", "Bob", "500"},
+		{"Dev", "Dave", "400"},
+		{"Dev 計", "", ""},
+		{"HR", "Eve", "100"},
+		{"HR 計", "", ""},
+		{"Sales", "Alice", "300"},
+		{"Sales", "Carol", "200"},
+		{"Sales 計", "", ""},
+	}, rows[:9])
+
+	formula, err := f.GetCellFormula("Employees", "C4")
+	require.NoError(t, err)
+	assert.Equal(t, "SUBTOTAL(109,C2:C3)", formula)
+	formula, err = f.GetCellFormula("Employees", "C10")
+	require.NoError(t, err)
+	assert.Equal(t, "SUBTOTAL(109,EmployeesTable[給与])", formula)
+
+	for row, want := range map[int]uint8{2: 1, 3: 1, 4: 0, 5: 1, 6: 0} {
+		level, err := f.GetRowOutlineLevel("Employees", row)
+		require.NoError(t, err)
+		assert.Equal(t, want, level, row)
+	}
+
+	tables, err := f.GetTables("Employees")
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	assert.Equal(t, "A1:C10", tables[0].Range)
+
+	assert.ErrorIs(t, s.SetGroupedRows(employees, "Team"), ErrUnknownColumn)
+}
+
+func TestSheetWithStreamWriter_SetGroupedRows(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	ssw, err := NewSheetWithStreamWriter[employee](f, "Employees", "A1", true, WithAutoWidth(8, 40))
+	require.NoError(t, err)
+	require.NoError(t, ssw.SetHeader())
+	require.NoError(t, ssw.SetGroupedRows(employees[:2], "部署"))
+	require.NoError(t, ssw.AddDefaultTable())
+	require.NoError(t, ssw.Flush())
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	saved, err := OpenFile(path)
+	require.NoError(t, err)
+
+	rows, err := saved.GetRows("Employees", excelize.Options{RawCellValue: true})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"部署", "氏名", "給与"},
+		{"Sales", "Alice", "300"},
+		{"Sales Total", "", ""},
+		{"Dev", "Bob", "500"},
+		{"Dev Total", "", ""},
+		{"", "", ""},
+	}, rows[:6])
+
+	formula, err := saved.GetCellFormula("Employees", "C3")
+	require.NoError(t, err)
+	assert.Equal(t, "SUBTOTAL(109,C2:C2)", formula)
+	level, err := saved.GetRowOutlineLevel("Employees", 4)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(1), level)
+}
+
+func TestSetGroupedRows_MergedKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(f *File) error
+	}{
+		{"Sheet", func(f *File) error {
+			s, err := NewSheet[employee](f, "Employees", "A1", true)
+			if err != nil {
+				return err
+			}
+			if err := s.SetHeader(); err != nil {
+				return err
+			}
+			if err := s.SetGroupedRows(employees, "部署", WithSortByKey(), WithMergedKeys()); err != nil {
+				return err
+			}
+			if err := s.AddDefaultTable(); !errors.Is(err, ErrMergedCells) {
+				return fmt.Errorf("AddDefaultTable: %v", err)
+			}
+			return s.Flush()
+		}},
+		{"SheetWithStreamWriter", func(f *File) error {
+			ssw, err := NewSheetWithStreamWriter[employee](f, "Employees", "A1", true)
+			if err != nil {
+				return err
+			}
+			if err := ssw.SetHeader(); err != nil {
+				return err
+			}
+			if err := ssw.SetGroupedRows(employees, "部署", WithSortByKey(), WithMergedKeys()); err != nil {
+				return err
+			}
+			if err := ssw.AddDefaultTable(); !errors.Is(err, ErrMergedCells) {
+				return fmt.Errorf("AddDefaultTable: %v", err)
+			}
+			return ssw.Flush()
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFile()
+			require.NoError(t, err)
+			require.NoError(t, tt.write(f))
+
+			path := filepath.Join(t.TempDir(), "test.xlsx")
+			require.NoError(t, f.SaveAs(path))
+			saved, err := OpenFile(path)
+			require.NoError(t, err)
+
+			assert.ElementsMatch(t, []string{"A2:A3", "A7:A8"}, mergedRanges(t, saved, "Employees"))
+			tables, err := saved.GetTables("Employees")
+			require.NoError(t, err)
+			assert.Empty(t, tables)
+
+			read, err := ReadRows[employee](saved, "Employees", "A1")
+			require.NoError(t, err)
+			departments := make([]string, 0, len(read))
+			for _, e := range read {
+				departments = append(departments, e.Department)
+			}
+			assert.Equal(t, []string{"Dev", "Dev", "HR", "Sales", "Sales"}, departments)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package exceltable
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WriteHTML writes rows to w as an HTML <table>.
+//
+// Header values are resolved in the same way as Sheet. Each cell satisfying a rule has
+// the rule tag as CSS class, and an inline style derived from the fill and font of the
+// registered rule style:
+//
+//	<td class="warn" style="background-color:#ffffaa">17</td>
+func WriteHTML[M any](w io.Writer, rows []*M) error {
+	sc, err := newDetachedSchema(reflect.TypeFor[M](), excelTag, csvTag)
+	if err != nil {
+		return err
+	}
+	styles := ruleCSS()
+
+	var sb strings.Builder
+	sb.WriteString("<table>\n<thead>\n<tr>")
+	for _, h := range sc.header {
+		fmt.Fprintf(&sb, "<th>%s</th>", html.EscapeString(h.(string)))
+	}
+	sb.WriteString("</tr>\n</thead>\n<tbody>\n")
+
+	for _, obj := range rows {
+		ptrV := reflect.ValueOf(obj)
+		v := ptrV.Elem()
+
+		sb.WriteString("<tr>")
+		for col, i := range sc.fields {
+			field := v.Field(i)
+			rule, err := sc.matchRule(col, ptrV, field)
+			if err != nil {
+				return err
+			}
+
+			value := html.EscapeString(formatValue(field))
+			switch {
+			case rule == nil:
+				fmt.Fprintf(&sb, "<td>%s</td>", value)
+			case styles[rule.tag] == "":
+				fmt.Fprintf(&sb, `<td class="%s">%s</td>`, html.EscapeString(rule.tag), value)
+			default:
+				fmt.Fprintf(&sb, `<td class="%s" style="%s">%s</td>`, html.EscapeString(rule.tag), html.EscapeString(styles[rule.tag]), value)
+			}
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>\n")
+
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+// ruleCSS returns the inline CSS of each registered rule tag.
+// If a tag is registered more than once, the rule with the highest priority is used.
+func ruleCSS() map[ruleTagType]string {
+	css := make(map[ruleTagType]string)
+	for _, r := range snapshotRules() {
+		if _, ok := css[r.tag]; !ok {
+			css[r.tag] = styleToCSS(r.style)
+		}
+	}
+	return css
+}
+
+// styleToCSS converts the fill and font of style to inline CSS.
+func styleToCSS(style *excelize.Style) string {
+	if style == nil {
+		return ""
+	}
+
+	decls := make([]string, 0)
+	if len(style.Fill.Color) > 0 && style.Fill.Color[0] != "" {
+		decls = append(decls, "background-color:"+cssColor(style.Fill.Color[0]))
+	}
+	if font := style.Font; font != nil {
+		if font.Color != "" {
+			decls = append(decls, "color:"+cssColor(font.Color))
+		}
+		if font.Bold {
+			decls = append(decls, "font-weight:bold")
+		}
+		if font.Italic {
+			decls = append(decls, "font-style:italic")
+		}
+		if font.Family != "" {
+			decls = append(decls, fmt.Sprintf("font-family:'%s'", font.Family))
+		}
+		if font.Size > 0 {
+			decls = append(decls, fmt.Sprintf("font-size:%gpt", font.Size))
+		}
+
+		lines := make([]string, 0, 2)
+		if font.Underline != "" && font.Underline != "none" {
+			lines = append(lines, "underline")
+		}
+		if font.Strike {
+			lines = append(lines, "line-through")
+		}
+		if len(lines) > 0 {
+			decls = append(decls, "text-decoration:"+strings.Join(lines, " "))
+		}
+	}
+	return strings.Join(decls, ";")
+}
+
+// cssColor converts color of excelize.Style such as "FFFFAA" or "#ffffaa" to CSS color.
+func cssColor(color string) string {
+	color = strings.TrimPrefix(color, "#")
+	if len(color) == 8 { // NOTE: ARGB.
+		color = color[2:]
+	}
+	return "#" + strings.ToLower(color)
+}
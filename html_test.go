@@ -0,0 +1,49 @@
+package exceltable
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteHTML(&buf, persons))
+
+	got := buf.String()
+	assert.Contains(t, got, "<tr><th>ID</th><th>氏名</th><th>年齢</th><th>住所</th><th>SpecialID</th></tr>\n")
+	assert.Contains(t, got, `<tr><td>ID-123456</td><td class="newface" style="background-color:#aaffaa">Alice</td>`+
+		`<td class="warn" style="background-color:#ffffaa">17</td><td></td><td></td></tr>`)
+	assert.Contains(t, got, `<td class="error" style="background-color:#ffaaaa"></td>`)
+	assert.Contains(t, got, "<td>京都</td>")
+}
+
+func Test_styleToCSS(t *testing.T) {
+	tests := []struct {
+		name  string
+		style *excelize.Style
+		want  string
+	}{
+		{
+			name:  "nil",
+			style: nil,
+			want:  "",
+		},
+		{
+			name: "fill and font",
+			style: &excelize.Style{
+				Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"FFAAAA"}},
+				Font: &excelize.Font{Bold: true, Color: "#FF0000", Strike: true, Underline: "single"},
+			},
+			want: "background-color:#ffaaaa;color:#ff0000;font-weight:bold;text-decoration:underline line-through",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, styleToCSS(tt.style))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package exceltable
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF for image.DecodeConfig.
+	_ "image/jpeg" // register JPEG for image.DecodeConfig.
+	_ "image/png"  // register PNG for image.DecodeConfig.
+	"os"
+	"reflect"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// imageTag is the tag inserting the picture of the field value, []byte or a file path, into the cell
+// instead of the value. The tag value is the row height in points, e.g. `image:"80"`, or the default if empty.
+const imageTag string = "image"
+
+// Row heights of rows with pictures in points.
+const (
+	defaultImageRowHeight = 60
+	maxRowHeight          = 409 // NOTE: Excel does not allow higher rows.
+)
+
+// imageHeights returns the row height of each column with the image tag, or nil if there is none.
+func imageHeights(sc *schema) ([]float64, error) {
+	var heights []float64
+	for col, i := range sc.fields {
+		field := sc.typ.Field(i)
+		tag, ok := field.Tag.Lookup(imageTag)
+		if !ok || sc.formulas[col] != "" {
+			continue
+		}
+
+		t := field.Type
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.String && !(t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8) {
+			return nil, fmt.Errorf("%w: %s: not []byte or string", ErrInvalidImage, field.Name)
+		}
+
+		height := float64(defaultImageRowHeight)
+		if tag != "" {
+			h, err := strconv.ParseFloat(tag, 64)
+			if err != nil || h <= 0 || h > maxRowHeight {
+				return nil, fmt.Errorf("%w: %s: %q", ErrInvalidImage, field.Name, tag)
+			}
+			height = h
+		}
+
+		if heights == nil {
+			heights = make([]float64, sc.tableWidth)
+		}
+		heights[col] = height
+	}
+	return heights, nil
+}
+
+// isImage reports whether column col has the image tag.
+func (s *sheetBase[M]) isImage(col int) bool {
+	return s.images != nil && s.images[col] > 0
+}
+
+// imageRowHeight returns the height of the row of v with pictures, or 0 if it has no pictures.
+func (s *sheetBase[M]) imageRowHeight(v reflect.Value) float64 {
+	height := 0.0
+	for col, h := range s.images {
+		if h > 0 && !isEmptyImage(v.Field(s.fields[col])) {
+			height = max(height, h)
+		}
+	}
+	return height
+}
+
+// addImage inserts the picture of field into the cell of column col in the current row,
+// scaled to the row height given by the image tag.
+// It is deferred until the sheet is flushed if deferred is true.
+func (s *sheetBase[M]) addImage(col int, field reflect.Value, deferred bool) error {
+	if isEmptyImage(field) {
+		return nil
+	}
+
+	cell := s.coordinatesToCellName(col, s.row)
+	height := s.images[col]
+	b, path := imageSource(field)
+	if deferred {
+		b = bytes.Clone(b) // NOTE: The caller may reuse the buffer for the next row.
+	}
+	fn := func() error {
+		if path != "" {
+			var err error
+			if b, err = os.ReadFile(path); err != nil {
+				return err
+			}
+		}
+
+		cfg, format, err := image.DecodeConfig(bytes.NewReader(b))
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidImage, cell, err)
+		}
+		scale := height * 4 / 3 / float64(cfg.Height) // NOTE: 1 point is 4/3 pixels.
+		return s.File.AddPictureFromBytes(s.name, cell, &excelize.Picture{
+			Extension: "." + format,
+			File:      b,
+			Format: &excelize.GraphicOptions{
+				ScaleX:          scale,
+				ScaleY:          scale,
+				LockAspectRatio: true,
+				Positioning:     "oneCell", // NOTE: The size does not depend on the row heights, unknown for StreamWriter.
+			},
+		})
+	}
+
+	if !deferred {
+		return fn()
+	}
+	s.deferred = append(s.deferred, fn)
+	return nil
+}
+
+// imageSource returns the picture data or the file path of field of a column with the image tag.
+func imageSource(field reflect.Value) (data []byte, path string) {
+	for field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return nil, ""
+		}
+		field = field.Elem()
+	}
+	if field.Kind() == reflect.String {
+		return nil, field.String()
+	}
+	return field.Bytes(), ""
+}
+
+// isEmptyImage reports whether field of a column with the image tag has no picture.
+func isEmptyImage(field reflect.Value) bool {
+	data, path := imageSource(field)
+	return len(data) == 0 && path == ""
+}
@@ -0,0 +1,146 @@
+package exceltable
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type product struct {
+	Name  string `excel:"Name"`
+	Photo []byte `excel:"Photo" image:"80"`
+	Icon  string `excel:"Icon" image:""`
+}
+
+func newPNG(t *testing.T) []byte {
+	t.Helper()
+	return newPNGSized(t, 10, 20)
+}
+
+func newPNGSized(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func Test_imageHeights(t *testing.T) {
+	sc, err := newDetachedSchema(reflect.TypeFor[product](), excelTag)
+	require.NoError(t, err)
+	heights, err := imageHeights(sc)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0, 80, defaultImageRowHeight}, heights)
+
+	type invalidHeight struct {
+		Photo []byte `image:"tall"`
+	}
+	sc, err = newDetachedSchema(reflect.TypeFor[invalidHeight](), excelTag)
+	require.NoError(t, err)
+	_, err = imageHeights(sc)
+	assert.ErrorIs(t, err, ErrInvalidImage)
+
+	type invalidType struct {
+		Photo int `image:""`
+	}
+	sc, err = newDetachedSchema(reflect.TypeFor[invalidType](), excelTag)
+	require.NoError(t, err)
+	_, err = imageHeights(sc)
+	assert.ErrorIs(t, err, ErrInvalidImage)
+}
+
+func TestSheet_Image(t *testing.T) {
+	b := newPNG(t)
+	path := filepath.Join(t.TempDir(), "icon.png")
+	require.NoError(t, os.WriteFile(path, b, 0o600))
+
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	s, err := NewSheet[product](f, "Products", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	require.NoError(t, s.SetRow(&product{"Apple", b, path}))
+	require.NoError(t, s.SetRow(&product{"Banana", nil, path}))
+	require.NoError(t, s.SetRow(&product{"Cherry", nil, ""}))
+	require.NoError(t, s.AddDefaultTable())
+	require.NoError(t, s.Flush())
+
+	for cell, want := range map[string]int{"B2": 1, "C2": 1, "B3": 0, "C3": 1, "C4": 0} {
+		pics, err := f.GetPictures("Products", cell)
+		require.NoError(t, err)
+		assert.Len(t, pics, want, cell)
+
+		v, err := f.GetCellValue("Products", cell)
+		require.NoError(t, err)
+		assert.Empty(t, v, cell)
+	}
+	for row, want := range map[int]float64{2: 80, 3: defaultImageRowHeight} {
+		height, err := f.GetRowHeight("Products", row)
+		require.NoError(t, err)
+		assert.Equal(t, want, height, row)
+	}
+
+	err = s.SetRow(&product{"Durian", []byte("not an image"), ""})
+	assert.ErrorIs(t, err, ErrInvalidImage)
+}
+
+func TestSheetWithStreamWriter_Image(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	ssw, err := NewSheetWithStreamWriter[product](f, "Products", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, ssw.SetHeader())
+	require.NoError(t, ssw.SetRow(&product{"Apple", newPNG(t), ""}))
+	require.NoError(t, ssw.AddDefaultTable())
+	require.NoError(t, ssw.Flush())
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	saved, err := OpenFile(path)
+	require.NoError(t, err)
+
+	pics, err := saved.GetPictures("Products", "B2")
+	require.NoError(t, err)
+	assert.Len(t, pics, 1)
+	height, err := saved.GetRowHeight("Products", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 80.0, height)
+}
+
+func TestSheetWithStreamWriter_ImageReusedBuffer(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	ssw, err := NewSheetWithStreamWriter[product](f, "Products", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, ssw.SetHeader())
+
+	small, large := newPNGSized(t, 10, 20), newPNGSized(t, 40, 20)
+	buf := make([]byte, max(len(small), len(large)))
+	for _, b := range [][]byte{small, large} {
+		n := copy(buf, b)
+		require.NoError(t, ssw.SetRow(&product{"Apple", buf[:n], ""}))
+	}
+	require.NoError(t, ssw.Flush())
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	saved, err := OpenFile(path)
+	require.NoError(t, err)
+
+	for cell, want := range map[string][]byte{"B2": small, "B3": large} {
+		pics, err := saved.GetPictures("Products", cell)
+		require.NoError(t, err)
+		require.Len(t, pics, 1)
+		assert.Equal(t, want, pics[0].File, cell)
+	}
+}
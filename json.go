@@ -0,0 +1,125 @@
+package exceltable
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+)
+
+// rulesJSONKey is the key of the object listing the rule tags matched for each field.
+const rulesJSONKey = "_rules"
+
+// JSONEncoder provides methods to write data of type M as JSON objects.
+//
+// Each object is keyed by the header value resolved in the order of json > excel > csv > field name,
+// and has the "_rules" object which maps the keys of the fields satisfying rules to the rule tags:
+//
+//	{"ID":"","氏名":"Carol","年齢":100,"_rules":{"ID":"error","年齢":"warn"}}
+type JSONEncoder[M any] struct {
+	*schema
+	w io.Writer
+}
+
+// NewJSONEncoder creates a new exceltable.JSONEncoder writing to w.
+//
+//	enc, _ := exceltable.NewJSONEncoder[YourStruct](w)
+func NewJSONEncoder[M any](w io.Writer) (*JSONEncoder[M], error) {
+	sc, err := newDetachedSchema(reflect.TypeFor[M](), jsonTag, excelTag, csvTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONEncoder[M]{sc, w}, nil
+}
+
+// Encode writes obj as a JSON object followed by a newline, i.e. a line of NDJSON.
+func (enc *JSONEncoder[M]) Encode(obj *M) error {
+	b, err := enc.marshal(obj)
+	if err != nil {
+		return err
+	}
+	_, err = enc.w.Write(append(b, '\n'))
+	return err
+}
+
+// marshal returns the JSON encoding of obj, keeping the order of columns.
+func (enc *JSONEncoder[M]) marshal(obj *M) ([]byte, error) {
+	ptrV := reflect.ValueOf(obj)
+	v := ptrV.Elem()
+
+	var buf, rulesBuf bytes.Buffer
+	buf.WriteByte('{')
+	rulesBuf.WriteByte('{')
+	for col, i := range enc.fields {
+		field := v.Field(i)
+		key, err := json.Marshal(enc.header[col])
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(getUnderlyingValue(field))
+		if err != nil {
+			return nil, err
+		}
+
+		if col > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+
+		rule, err := enc.matchRule(col, ptrV, field)
+		if err != nil {
+			return nil, err
+		}
+		if rule != nil {
+			tag, err := json.Marshal(rule.tag)
+			if err != nil {
+				return nil, err
+			}
+
+			if rulesBuf.Len() > 1 {
+				rulesBuf.WriteByte(',')
+			}
+			rulesBuf.Write(key)
+			rulesBuf.WriteByte(':')
+			rulesBuf.Write(tag)
+		}
+	}
+	rulesBuf.WriteByte('}')
+
+	if len(enc.fields) > 0 {
+		buf.WriteByte(',')
+	}
+	buf.WriteString(`"` + rulesJSONKey + `":`)
+	buf.Write(rulesBuf.Bytes())
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// WriteJSON writes rows to w as a JSON array of the objects encoded by JSONEncoder.
+func WriteJSON[M any](w io.Writer, rows []*M) error {
+	enc, err := NewJSONEncoder[M](w)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, obj := range rows {
+		b, err := enc.marshal(obj)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(b)
+	}
+	buf.WriteString("]\n")
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
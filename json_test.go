@@ -0,0 +1,50 @@
+package exceltable
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewJSONEncoder[person](&buf)
+	require.NoError(t, err)
+	for _, p := range persons {
+		require.NoError(t, enc.Encode(p))
+	}
+
+	want := "" +
+		`{"ID":"ID-123456","氏名":"Alice","年齢":17,"住所":"","SpecialID":"","_rules":{"氏名":"newface","年齢":"warn"}}` + "\n" +
+		`{"ID":"ID-112358","氏名":"Bob","年齢":32,"住所":"Boston","SpecialID":null,"_rules":{"SpecialID":"error"}}` + "\n" +
+		`{"ID":"","氏名":"Carol","年齢":100,"住所":"京都","SpecialID":"SID-999999","_rules":{"ID":"error","年齢":"warn","SpecialID":"warn"}}` + "\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestJSONEncoder_JSONTag(t *testing.T) {
+	type item struct {
+		Code  string `json:"code,omitempty" error:"zero"`
+		Name  string `json:",omitempty" excel:"名前"`
+		Price int    `json:"-"`
+	}
+
+	var buf bytes.Buffer
+	enc, err := NewJSONEncoder[item](&buf)
+	require.NoError(t, err)
+	require.NoError(t, enc.Encode(&item{Name: "pen", Price: 100}))
+	assert.Equal(t, `{"code":"","名前":"pen","_rules":{"code":"error"}}`+"\n", buf.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, persons))
+
+	var got []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Len(t, got, 3)
+	assert.Equal(t, "Bob", got[1]["氏名"])
+	assert.Equal(t, map[string]any{"SpecialID": "error"}, got[1][rulesJSONKey])
+}
@@ -0,0 +1,232 @@
+package exceltable
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// defaultLayoutGap is the default number of empty rows or columns between tables placed by SheetLayout.
+const defaultLayoutGap = 1
+
+// LayoutOption configures SheetLayout.
+type LayoutOption func(*layoutOptions)
+
+type layoutOptions struct {
+	horizontal bool
+	gap        int
+}
+
+func newLayoutOptions(opts []LayoutOption) *layoutOptions {
+	o := &layoutOptions{gap: defaultLayoutGap}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithHorizontalLayout places tables beside each other from left to right, instead of below each other.
+//
+// It is not supported by PlaceSheetWithStreamWriter, since rows must be written in order.
+func WithHorizontalLayout() LayoutOption {
+	return func(o *layoutOptions) {
+		o.horizontal = true
+	}
+}
+
+// WithLayoutGap sets the number of empty rows, or columns for WithHorizontalLayout, between tables.
+// The default is 1.
+func WithLayoutGap(gap int) LayoutOption {
+	return func(o *layoutOptions) {
+		o.gap = max(gap, 0)
+	}
+}
+
+// placedTable is a table placed by SheetLayout.
+type placedTable interface {
+	bounds() (x1, y1, x2, y2 int)
+	seal() error
+	Flush() error
+}
+
+// SheetLayout places multiple tables in a sheet, below or beside each other, without overlapping.
+//
+//	l, _ := exceltable.NewSheetLayout(f, "Dashboard", "A1", true)
+//	summary, _ := exceltable.PlaceSheet[Summary](l)
+//	// write summary...
+//	detail, _ := exceltable.PlaceSheet[Detail](l) // placed below summary.
+//	// write detail...
+//	_ = l.Flush()
+//
+// In the vertical layout, which is the default, placing a table fixes the range of the tables above,
+// and SetRow on them returns ErrTableOverlap.
+type SheetLayout struct {
+	File           *File
+	name           string                 // sheet name
+	x, y           int                    // starting cell coordinates
+	opts           *layoutOptions         // layout options
+	tables         []placedTable          // tables placed in order
+	streamWriter   *excelize.StreamWriter // StreamWriter shared by tables placed by PlaceSheetWithStreamWriter
+	hasStreamTable bool                   // whether a table is added by the shared StreamWriter
+}
+
+// NewSheetLayout creates a new sheet with the given name, and returns exceltable.SheetLayout
+// placing tables from the starting cell.
+// It returns ErrSheetExists if the sheet exists.
+func NewSheetLayout(f *File, name, cell string, active bool, opts ...LayoutOption) (*SheetLayout, error) {
+	x, y, err := excelize.CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := f.GetSheetIndex(name)
+	if err != nil {
+		return nil, err
+	}
+	if idx != -1 {
+		return nil, fmt.Errorf("%w: %q", ErrSheetExists, name)
+	}
+	if idx, err = f.NewSheet(name); err != nil {
+		return nil, err
+	}
+	if active {
+		f.SetActiveSheet(idx)
+	}
+
+	return &SheetLayout{
+		File: f,
+		name: name,
+		x:    x,
+		y:    y,
+		opts: newLayoutOptions(opts),
+	}, nil
+}
+
+// PlaceSheet creates a new exceltable.Sheet placed next to the tables placed so far.
+//
+// It returns ErrStreamLayout if the layout has tables placed by PlaceSheetWithStreamWriter.
+func PlaceSheet[M any](l *SheetLayout, opts ...SheetOption) (*Sheet[M], error) {
+	if l.streamWriter != nil {
+		return nil, fmt.Errorf("%w: cannot be mixed with Sheet", ErrStreamLayout)
+	}
+
+	x, y, err := l.next()
+	if err != nil {
+		return nil, err
+	}
+	sb, err := newSheetBaseAt[M](l.File, l.name, x, y, false, true, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := newSheet(sb)
+	if err != nil {
+		return nil, err
+	}
+	l.tables = append(l.tables, s)
+	return s, nil
+}
+
+// PlaceSheetWithStreamWriter creates a new exceltable.SheetWithStreamWriter placed below the tables placed so far.
+// The tables share one excelize.StreamWriter, which is flushed by SheetLayout.Flush.
+//
+// Since excelize.StreamWriter writes rows in order, supports only one table per sheet,
+// and sets column widths only before writing rows, the following return ErrStreamLayout:
+//   - PlaceSheetWithStreamWriter with WithHorizontalLayout, or with tables placed by PlaceSheet.
+//   - PlaceSheetWithStreamWriter of the second table with WithAutoWidth or columns with the "hidden" option.
+//   - AddTable on the second table.
+//
+// Call AddTable on a table before placing the next one, so that its totals row is written in order.
+func PlaceSheetWithStreamWriter[M any](l *SheetLayout, opts ...SheetOption) (*SheetWithStreamWriter[M], error) {
+	if l.opts.horizontal {
+		return nil, fmt.Errorf("%w: horizontal layout", ErrStreamLayout)
+	}
+	if len(l.tables) > 0 && l.streamWriter == nil {
+		return nil, fmt.Errorf("%w: cannot be mixed with Sheet", ErrStreamLayout)
+	}
+	if len(l.tables) > 0 {
+		if err := checkColumnWidths[M](opts); err != nil {
+			return nil, err
+		}
+	}
+
+	x, y, err := l.next()
+	if err != nil {
+		return nil, err
+	}
+	sb, err := newSheetBaseAt[M](l.File, l.name, x, y, false, true, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.streamWriter == nil {
+		if l.streamWriter, err = l.File.File.NewStreamWriter(l.name); err != nil {
+			return nil, err
+		}
+	}
+	ssw, err := newSheetWithStreamWriter(sb, l.streamWriter, l)
+	if err != nil {
+		return nil, err
+	}
+	l.tables = append(l.tables, ssw)
+	return ssw, nil
+}
+
+// checkColumnWidths returns ErrStreamLayout if a table of type M with opts sets column widths,
+// which excelize.StreamWriter cannot set after the rows of the tables above are written.
+func checkColumnWidths[M any](opts []SheetOption) error {
+	if newSheetOptions(opts).autoWidth != nil {
+		return fmt.Errorf("%w: WithAutoWidth on a table other than the first", ErrStreamLayout)
+	}
+
+	sc, err := newDetachedSchema(reflect.TypeFor[M](), excelTag, csvTag)
+	if err != nil {
+		return err
+	}
+	sc.addFormulaColumns()
+	if len(hiddenColumns(sc)) > 0 {
+		return fmt.Errorf("%w: hidden columns on a table other than the first", ErrStreamLayout)
+	}
+	return nil
+}
+
+// next returns the starting cell coordinates of the next table, and seals the tables above in the vertical layout.
+func (l *SheetLayout) next() (x, y int, err error) {
+	if len(l.tables) == 0 {
+		return l.x, l.y, nil
+	}
+
+	x, y = l.x, l.y
+	for _, t := range l.tables {
+		_, _, x2, y2 := t.bounds()
+		if l.opts.horizontal {
+			x = max(x, x2+l.opts.gap+1)
+		} else {
+			y = max(y, y2+l.opts.gap+1)
+		}
+	}
+	if l.opts.horizontal {
+		return x, y, nil // NOTE: Tables beside each other can grow downward without overlapping.
+	}
+
+	for _, t := range l.tables {
+		if err := t.seal(); err != nil {
+			return 0, 0, err
+		}
+	}
+	return x, y, nil
+}
+
+// Flush flushes all the tables placed in order, and then the shared excelize.StreamWriter if any.
+func (l *SheetLayout) Flush() error {
+	for _, t := range l.tables {
+		if err := t.Flush(); err != nil {
+			return err
+		}
+	}
+	if l.streamWriter != nil {
+		return l.streamWriter.Flush()
+	}
+	return nil
+}
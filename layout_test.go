@@ -0,0 +1,152 @@
+package exceltable
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type layoutSummary struct {
+	Count int `excel:"Count"`
+}
+
+func TestSheetLayout_Vertical(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	l, err := NewSheetLayout(f, "Dashboard", "B2", true, WithLayoutGap(2))
+	require.NoError(t, err)
+
+	summary, err := PlaceSheet[layoutSummary](l)
+	require.NoError(t, err)
+	require.NoError(t, summary.SetHeader())
+	require.NoError(t, summary.SetRow(&layoutSummary{Count: len(persons)}))
+	require.NoError(t, summary.AddDefaultTable())
+
+	detail, err := PlaceSheet[person](l)
+	require.NoError(t, err)
+	require.NoError(t, detail.SetHeader())
+	for _, p := range persons {
+		require.NoError(t, detail.SetRow(p))
+	}
+	require.NoError(t, detail.AddDefaultTable())
+
+	assert.ErrorIs(t, summary.SetRow(&layoutSummary{}), ErrTableOverlap)
+	require.NoError(t, l.Flush())
+
+	tables, err := f.GetTables("Dashboard")
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+	assert.Equal(t, "B2:C3", tables[0].Range) // NOTE: Tables have at least two columns.
+	assert.Equal(t, "DashboardTable", tables[0].Name)
+	assert.Equal(t, "B6:F9", tables[1].Range)
+	assert.Equal(t, "DashboardTable_2", tables[1].Name)
+}
+
+func TestSheetLayout_Horizontal(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	l, err := NewSheetLayout(f, "Dashboard", "A1", true, WithHorizontalLayout())
+	require.NoError(t, err)
+
+	detail, err := PlaceSheet[person](l)
+	require.NoError(t, err)
+	require.NoError(t, detail.SetHeader())
+
+	summary, err := PlaceSheet[layoutSummary](l)
+	require.NoError(t, err)
+	require.NoError(t, summary.SetHeader())
+
+	// NOTE: Tables beside each other can still grow downward.
+	for _, p := range persons {
+		require.NoError(t, detail.SetRow(p))
+	}
+	require.NoError(t, summary.SetRow(&layoutSummary{Count: len(persons)}))
+	require.NoError(t, detail.AddDefaultTable())
+	require.NoError(t, summary.AddDefaultTable())
+	require.NoError(t, l.Flush())
+
+	tables, err := f.GetTables("Dashboard")
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+	assert.Equal(t, "A1:E4", tables[0].Range)
+	assert.Equal(t, "G1:H2", tables[1].Range)
+}
+
+func TestSheetLayout_StreamWriter(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	l, err := NewSheetLayout(f, "Dashboard", "A1", true)
+	require.NoError(t, err)
+
+	summary, err := PlaceSheetWithStreamWriter[layoutSummary](l)
+	require.NoError(t, err)
+	require.NoError(t, summary.SetHeader())
+	require.NoError(t, summary.SetRow(&layoutSummary{Count: len(persons)}))
+
+	detail, err := PlaceSheetWithStreamWriter[person](l)
+	require.NoError(t, err)
+	require.NoError(t, detail.SetHeader())
+	for _, p := range persons {
+		require.NoError(t, detail.SetRow(p))
+	}
+	require.NoError(t, detail.AddDefaultTable())
+	assert.ErrorIs(t, summary.AddDefaultTable(), ErrStreamLayout)
+	assert.ErrorIs(t, summary.SetRow(&layoutSummary{}), ErrTableOverlap)
+	require.NoError(t, l.Flush())
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	saved, err := OpenFile(path)
+	require.NoError(t, err)
+
+	rows, err := saved.GetRows("Dashboard")
+	require.NoError(t, err)
+	require.Len(t, rows, 7)
+	assert.Equal(t, []string{"Count"}, rows[0])
+	assert.Equal(t, "3", rows[1][0])
+	assert.Empty(t, rows[2])
+	assert.Equal(t, "ID", rows[3][0])
+
+	tables, err := saved.GetTables("Dashboard")
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	assert.Equal(t, "A4:E7", tables[0].Range)
+}
+
+func TestSheetLayout_Errors(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	_, err = NewSheetLayout(f, "Sheet1", "A1", true)
+	assert.ErrorIs(t, err, ErrSheetExists)
+
+	l, err := NewSheetLayout(f, "Horizontal", "A1", true, WithHorizontalLayout())
+	require.NoError(t, err)
+	_, err = PlaceSheetWithStreamWriter[person](l)
+	assert.ErrorIs(t, err, ErrStreamLayout)
+
+	l, err = NewSheetLayout(f, "Mixed", "A1", true)
+	require.NoError(t, err)
+	_, err = PlaceSheet[person](l)
+	require.NoError(t, err)
+	_, err = PlaceSheetWithStreamWriter[person](l)
+	assert.ErrorIs(t, err, ErrStreamLayout)
+
+	l, err = NewSheetLayout(f, "Widths", "A1", true)
+	require.NoError(t, err)
+	first, err := PlaceSheetWithStreamWriter[customer](l, WithAutoWidth(8, 40))
+	require.NoError(t, err)
+	require.NoError(t, first.SetHeader())
+	_, err = PlaceSheetWithStreamWriter[person](l, WithAutoWidth(8, 40))
+	assert.ErrorIs(t, err, ErrStreamLayout)
+	_, err = PlaceSheetWithStreamWriter[customer](l)
+	assert.ErrorIs(t, err, ErrStreamLayout)
+	_, err = PlaceSheetWithStreamWriter[person](l)
+	require.NoError(t, err)
+	require.NoError(t, l.Flush())
+}
@@ -0,0 +1,135 @@
+package exceltable
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Header values of the legend sheet.
+var (
+	legendRuleHeader      = []any{"Rule", "Style", "Priority"}
+	legendPredicateHeader = []any{"Rule", "Predicate", "Description", "Hits"}
+)
+
+// usageKey identifies a predicate used for a rule tag.
+type usageKey struct {
+	tag ruleTagType
+	key predKeyType
+}
+
+// ruleUsage holds the predicates used by columns and their hit counts.
+type ruleUsage struct {
+	sync.Mutex
+	keys []usageKey // in order of first use
+	hits map[usageKey]int
+}
+
+func newRuleUsage() *ruleUsage {
+	return &ruleUsage{
+		keys: make([]usageKey, 0),
+		hits: make(map[usageKey]int),
+	}
+}
+
+// use records that the predicates of rulesList are used by columns.
+func (u *ruleUsage) use(rulesList ...[]*sheetRule) {
+	u.Lock()
+	defer u.Unlock()
+
+	for _, rules := range rulesList {
+		for _, rule := range rules {
+			k := usageKey{rule.tag, rule.key}
+			if _, ok := u.hits[k]; !ok {
+				u.keys = append(u.keys, k)
+				u.hits[k] = 0
+			}
+		}
+	}
+}
+
+// hit increments the hit count of the predicate of rule.
+func (u *ruleUsage) hit(rule *sheetRule) {
+	u.Lock()
+	defer u.Unlock()
+
+	u.hits[usageKey{rule.tag, rule.key}]++
+}
+
+// AddLegendSheet adds a new sheet which describes the rules and predicates.
+//
+// It lists each rule with a swatch cell in its actual style and its priority, followed by
+// the predicates used by columns in this workbook with their descriptions and hit counts.
+// It should be called after writing all sheets so that the hit counts are complete.
+// It returns ErrSheetExists if the sheet exists.
+func (f *File) AddLegendSheet(name string) error {
+	if _, _, err := f.prepareSheet(name, false); err != nil {
+		return err
+	}
+
+	headerStyleID, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+
+	row := 1
+	setRow := func(values []any, styleID int) error {
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		if err := f.SetSheetRow(name, cell, &values); err != nil {
+			return err
+		}
+		if styleID != 0 {
+			end, _ := excelize.CoordinatesToCellName(len(values), row)
+			if err := f.SetCellStyle(name, cell, end, styleID); err != nil {
+				return err
+			}
+		}
+		row++
+		return nil
+	}
+
+	if err := setRow(legendRuleHeader, headerStyleID); err != nil {
+		return err
+	}
+	for _, rule := range f.rules {
+		if err := setRow([]any{rule.tag, rule.tag, rule.priority}, 0); err != nil {
+			return err
+		}
+		swatch, _ := excelize.CoordinatesToCellName(2, row-1)
+		if err := f.SetCellStyle(name, swatch, swatch, rule.styleID); err != nil {
+			return err
+		}
+	}
+
+	row++ // NOTE: Leave a blank row between sections.
+	if err := setRow(legendPredicateHeader, headerStyleID); err != nil {
+		return err
+	}
+
+	f.usage.Lock()
+	keys := slices.Clone(f.usage.keys)
+	hits := make(map[usageKey]int, len(f.usage.hits))
+	for k, v := range f.usage.hits {
+		hits[k] = v
+	}
+	f.usage.Unlock()
+
+	for _, rule := range f.rules { // NOTE: In descending order of priority.
+		for _, k := range keys {
+			if k.tag != rule.tag {
+				continue
+			}
+
+			description := ""
+			if d, ok := descriptions.Load(k.key); ok {
+				description = d.(string)
+			}
+			if err := setRow([]any{k.tag, k.key, description, hits[k]}, 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
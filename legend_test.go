@@ -0,0 +1,57 @@
+package exceltable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_AddLegendSheet(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[person](f, "test", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	for _, p := range persons {
+		require.NoError(t, s.SetRow(p))
+	}
+
+	require.NoError(t, f.AddLegendSheet("Legend"))
+
+	rows, err := f.GetRows("Legend")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Rule", "Style", "Priority"}, rows[0])
+	assert.Equal(t, []string{"error", "error", "99"}, rows[1])
+	assert.Equal(t, []string{"warn", "warn", "98"}, rows[2])
+
+	styleID, err := f.GetCellStyle("Legend", "B3")
+	require.NoError(t, err)
+	assert.Equal(t, fileRuleByTag(t, f, warnTag).styleID, styleID)
+
+	i := len(f.rules) + 1
+	assert.Empty(t, rows[i])
+	assert.Equal(t, []string{"Rule", "Predicate", "Description", "Hits"}, rows[i+1])
+	assert.Equal(t, []string{"error", "zero", "", "1"}, rows[i+2])
+	assert.Equal(t, []string{"error", "nil", "", "1"}, rows[i+3])
+	assert.Equal(t, []string{"warn", "IsChild", "younger than 18", "1"}, rows[i+4])
+	assert.Equal(t, []string{"warn", "IsOld", "", "1"}, rows[i+5])
+	assert.Equal(t, []string{"warn", "notZero", "", "1"}, rows[i+6])
+	assert.Equal(t, []string{"newface", "isNewFace", "", "1"}, rows[i+7])
+	assert.Len(t, rows, i+8)
+}
+
+func TestFile_AddLegendSheet_SheetExists(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[person](f, "Persons", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	require.NoError(t, s.SetRow(persons[0]))
+
+	assert.ErrorIs(t, f.AddLegendSheet("Persons"), ErrSheetExists)
+	assert.ErrorIs(t, f.AddLegendSheet("persons"), ErrSheetExists)
+	rows, err := f.GetRows("Persons")
+	require.NoError(t, err)
+	assert.Len(t, rows, 2)
+}
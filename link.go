@@ -0,0 +1,246 @@
+package exceltable
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// linkTag is the tag making cells of the column hyperlinks:
+//   - `link:"url"` links to the URL of the field value.
+//   - `link:"#Customers"` links to the row of the sheet "Customers" whose key column has the field value.
+const linkTag string = "link"
+
+// urlLink is the value of the link tag for external URLs.
+const urlLink string = "url"
+
+// keyOption is the option of the excel tag indicating the key column, which internal links refer to.
+const keyOption string = "key"
+
+// hyperlinkFontColor is the font color of hyperlink cells.
+const hyperlinkFontColor = "0563C1"
+
+// columnLinks returns the link tag of each column, or nil if there is none.
+func columnLinks(sc *schema) []string {
+	var links []string
+	for col, i := range sc.fields {
+		link := sc.typ.Field(i).Tag.Get(linkTag)
+		if link == "" || sc.formulas[col] != "" {
+			continue
+		}
+		if links == nil {
+			links = make([]string, sc.tableWidth)
+		}
+		links[col] = link
+	}
+	return links
+}
+
+// keyColumn returns the first column with the "key" option, or -1 if there is none.
+func keyColumn(sc *schema) int {
+	for col, i := range sc.fields {
+		if sc.formulas[col] == "" && hasTagOption(sc.typ.Field(i), excelTag, keyOption) {
+			return col
+		}
+	}
+	return -1
+}
+
+// addKey registers cell of sheet having key value in the key column. The first cell is kept for duplicates.
+func (f *File) addKey(sheet, value, cell string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.keys == nil {
+		f.keys = make(map[string]map[string]string)
+	}
+	sheet = strings.ToLower(sheet)
+	if f.keys[sheet] == nil {
+		f.keys[sheet] = make(map[string]string)
+	}
+	if _, ok := f.keys[sheet][value]; !ok {
+		f.keys[sheet][value] = cell
+	}
+}
+
+// lookupKey returns the cell of sheet having key value in the key column.
+func (f *File) lookupKey(sheet, value string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cell, ok := f.keys[strings.ToLower(sheet)][value]
+	return cell, ok
+}
+
+// pendingLink is a link deferred until the sheet linked is flushed.
+type pendingLink struct {
+	from string       // sheet name of the cell linking
+	set  func() error // sets the link
+}
+
+// whenFlushed calls set if sheet is flushed, or defers it until sheet is flushed otherwise.
+// from is the sheet name of the cell linking.
+func (f *File) whenFlushed(from, sheet string, set func() error) error {
+	f.mu.Lock()
+	sheet = strings.ToLower(sheet)
+	if !f.flushed[sheet] {
+		if f.links == nil {
+			f.links = make(map[string][]pendingLink)
+		}
+		f.links[sheet] = append(f.links[sheet], pendingLink{from, set})
+		f.mu.Unlock()
+		return nil
+	}
+	f.mu.Unlock()
+	return set()
+}
+
+// isFlushed reports whether sheet is flushed.
+func (f *File) isFlushed(sheet string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.flushed[strings.ToLower(sheet)]
+}
+
+// markFlushed marks sheet as flushed, and returns the links to sheet deferred until then.
+func (f *File) markFlushed(sheet string) []pendingLink {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.flushed == nil {
+		f.flushed = make(map[string]bool)
+	}
+	sheet = strings.ToLower(sheet)
+	f.flushed[sheet] = true
+	links := f.links[sheet]
+	delete(f.links, sheet)
+	return links
+}
+
+// hyperlinkStyle returns the ID of the style which is the same as styleID but underlined in the hyperlink color.
+// The style is created at the first call and reused afterwards.
+func (f *File) hyperlinkStyle(styleID int) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if id, ok := f.hyperlinks[styleID]; ok {
+		return id, nil
+	}
+
+	style := &excelize.Style{}
+	if styleID != 0 {
+		var err error
+		if style, err = f.GetStyle(styleID); err != nil {
+			return 0, err
+		}
+	}
+	font := excelize.Font{}
+	if style.Font != nil {
+		font = *style.Font
+	}
+	font.Color, font.Underline = hyperlinkFontColor, "single"
+	style.Font = &font
+
+	id, err := f.NewStyle(style)
+	if err != nil {
+		return 0, err
+	}
+	if f.hyperlinks == nil {
+		f.hyperlinks = make(map[int]int)
+	}
+	f.hyperlinks[styleID] = id
+	return id, nil
+}
+
+// recordKey registers the value of the key column of v written in the current row.
+func (s *sheetBase[M]) recordKey(v reflect.Value) {
+	if s.keyCol == -1 {
+		return
+	}
+	if value := formatValue(v.Field(s.fields[s.keyCol])); value != "" {
+		s.File.addKey(s.name, value, s.coordinatesToCellName(s.keyCol, s.row))
+	}
+}
+
+// linkStyle returns the style ID of the data cell of column col with the rule style styleID (0 if none),
+// which is underlined in the hyperlink color if col has the link tag.
+func (s *sheetBase[M]) linkStyle(col int, field reflect.Value, styleID int) (int, error) {
+	if s.links == nil || s.links[col] == "" || formatValue(field) == "" {
+		return styleID, nil
+	}
+	return s.File.hyperlinkStyle(styleID)
+}
+
+// setLink makes the cell of column col in the current row a hyperlink to the value of field.
+// Internal links are set when both the sheet and the sheet linked are flushed, since the sheet linked
+// may not be written yet, and external links are set when the sheet is flushed if deferAll is true.
+// Internal links are not set if no row of the sheet linked has the value.
+//
+// If deferAll is true, internal links cannot wait for the sheet linked, since excelize.StreamWriter
+// discards hyperlinks added after it is flushed, so the sheet linked is checked by checkLinkTargets.
+func (s *sheetBase[M]) setLink(col int, field reflect.Value, deferAll bool) error {
+	if s.links == nil || s.links[col] == "" {
+		return nil
+	}
+	value := formatValue(field)
+	if value == "" {
+		return nil
+	}
+
+	cell := s.coordinatesToCellName(col, s.row)
+	if s.links[col] == urlLink {
+		fn := func() error {
+			return s.File.SetCellHyperLink(s.name, cell, value, "External")
+		}
+		if !deferAll {
+			return fn()
+		}
+		s.deferred = append(s.deferred, fn)
+		return nil
+	}
+
+	sheet := strings.TrimPrefix(s.links[col], "#")
+	link := func() error {
+		ref, ok := s.File.lookupKey(sheet, value)
+		if !ok {
+			return nil
+		}
+		return s.File.SetCellHyperLink(s.name, cell, quoteSheetName(sheet)+"!"+ref, "Location")
+	}
+	if !deferAll {
+		s.deferred = append(s.deferred, func() error {
+			return s.File.whenFlushed(s.name, sheet, link)
+		})
+		return nil
+	}
+	if !slices.Contains(s.linkTargets, sheet) {
+		s.linkTargets = append(s.linkTargets, sheet)
+	}
+	s.deferred = append(s.deferred, link) // NOTE: The sheet linked is flushed, or is the sheet itself.
+	return nil
+}
+
+// checkLinkTargets returns ErrLinkTarget if a sheet linked by SheetWithStreamWriter is not flushed yet.
+func (s *sheetBase[M]) checkLinkTargets() error {
+	for _, sheet := range s.linkTargets {
+		if !strings.EqualFold(sheet, s.name) && !s.File.isFlushed(sheet) {
+			return fmt.Errorf("%w: %q must be flushed before %q", ErrLinkTarget, sheet, s.name)
+		}
+	}
+	return nil
+}
+
+// resolveLinks marks the sheet as flushed, whose keys are all registered by then,
+// and sets the links to the sheet deferred until then.
+func (s *sheetBase[M]) resolveLinks() error {
+	for _, link := range s.File.markFlushed(s.name) {
+		if err := link.set(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package exceltable
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type linkCustomer struct {
+	ID   string `excel:"ID,key"`
+	Name string `excel:"Name"`
+	Site string `excel:"Site" link:"url"`
+}
+
+type linkOrder struct {
+	ID       string `excel:"Order"`
+	Customer string `excel:"Customer" link:"#Customer List"`
+}
+
+func TestSheet_Link(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	// NOTE: The sheet linked may be written after the sheet linking to it.
+	orders, err := NewSheet[linkOrder](f, "Orders", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, orders.SetHeader())
+	for _, o := range []*linkOrder{{"O-1", "C-2"}, {"O-2", "C-9"}, {"O-3", ""}} {
+		require.NoError(t, orders.SetRow(o))
+	}
+
+	customers, err := NewSheet[linkCustomer](f, "Customer List", "B2", false)
+	require.NoError(t, err)
+	require.NoError(t, customers.SetHeader())
+	for _, c := range []*linkCustomer{{"C-1", "Alice", "https://example.com/alice"}, {"C-2", "Bob", ""}} {
+		require.NoError(t, customers.SetRow(c))
+	}
+	require.NoError(t, customers.Flush())
+	require.NoError(t, orders.Flush())
+
+	ok, target, err := f.GetCellHyperLink("Orders", "B2")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "'Customer List'!B4", target)
+	for _, cell := range []string{"B3", "B4"} { // unknown and empty keys.
+		ok, _, err := f.GetCellHyperLink("Orders", cell)
+		require.NoError(t, err)
+		assert.False(t, ok, cell)
+	}
+
+	ok, target, err = f.GetCellHyperLink("Customer List", "D3")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "https://example.com/alice", target)
+
+	styleID, err := f.GetCellStyle("Customer List", "D3")
+	require.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	require.NoError(t, err)
+	assert.Equal(t, "single", style.Font.Underline)
+	styleID, err = f.GetCellStyle("Customer List", "D4")
+	require.NoError(t, err)
+	assert.Zero(t, styleID)
+}
+
+func TestSheetWithStreamWriter_Link(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	customers, err := NewSheetWithStreamWriter[linkCustomer](f, "Customer List", "A1", false)
+	require.NoError(t, err)
+	require.NoError(t, customers.SetHeader())
+	require.NoError(t, customers.SetRow(&linkCustomer{"C-1", "Alice", "https://example.com/alice"}))
+	require.NoError(t, customers.Flush())
+
+	orders, err := NewSheetWithStreamWriter[linkOrder](f, "Orders", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, orders.SetHeader())
+	require.NoError(t, orders.SetRow(&linkOrder{"O-1", "C-1"}))
+	require.NoError(t, orders.Flush())
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	saved, err := OpenFile(path)
+	require.NoError(t, err)
+
+	ok, target, err := saved.GetCellHyperLink("Orders", "B2")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "'Customer List'!A2", target)
+
+	ok, target, err = saved.GetCellHyperLink("Customer List", "C2")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "https://example.com/alice", target)
+}
+
+func TestSheet_LinkFlushedLater(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	orders, err := NewSheet[linkOrder](f, "Orders", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, orders.SetHeader())
+	require.NoError(t, orders.SetRow(&linkOrder{"O-1", "C-1"}))
+	require.NoError(t, orders.Flush())
+
+	customers, err := NewSheetWithStreamWriter[linkCustomer](f, "Customer List", "A1", false)
+	require.NoError(t, err)
+	require.NoError(t, customers.SetHeader())
+	require.NoError(t, customers.SetRow(&linkCustomer{"C-1", "Alice", ""}))
+
+	ok, _, err := f.GetCellHyperLink("Orders", "B2")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, customers.Flush())
+	ok, target, err := f.GetCellHyperLink("Orders", "B2")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "'Customer List'!A2", target)
+}
+
+func TestSheetWithStreamWriter_LinkFlushedLater(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	customers, err := NewSheet[linkCustomer](f, "Customer List", "A1", false)
+	require.NoError(t, err)
+	require.NoError(t, customers.SetHeader())
+	require.NoError(t, customers.SetRow(&linkCustomer{"C-1", "Alice", ""}))
+
+	orders, err := NewSheetWithStreamWriter[linkOrder](f, "Orders", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, orders.SetHeader())
+	require.NoError(t, orders.SetRow(&linkOrder{"O-1", "C-1"}))
+	assert.ErrorIs(t, orders.Flush(), ErrLinkTarget)
+
+	require.NoError(t, customers.Flush())
+	require.NoError(t, orders.Flush())
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	saved, err := OpenFile(path)
+	require.NoError(t, err)
+
+	rows, err := saved.GetRows("Orders")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Order", "Customer"}, {"O-1", "C-1"}}, rows)
+	ok, target, err := saved.GetCellHyperLink("Orders", "B2")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "'Customer List'!A2", target)
+}
+
+func TestNewSheet_OverwriteLinked(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	customers, err := NewSheet[linkCustomer](f, "Customer List", "A1", false)
+	require.NoError(t, err)
+	require.NoError(t, customers.SetHeader())
+	require.NoError(t, customers.SetRow(&linkCustomer{"C-1", "Alice", ""}))
+	require.NoError(t, customers.Flush())
+
+	// NOTE: The keys and the flushed state of the replaced sheet are not taken over.
+	customers, err = NewSheet[linkCustomer](f, "Customer List", "A1", false, WithOverwrite())
+	require.NoError(t, err)
+	require.NoError(t, customers.SetHeader())
+	require.NoError(t, customers.SetRow(&linkCustomer{"C-2", "Bob", ""}))
+
+	stream, err := NewSheetWithStreamWriter[linkOrder](f, "Stream Orders", "A1", false)
+	require.NoError(t, err)
+	require.NoError(t, stream.SetHeader())
+	require.NoError(t, stream.SetRow(&linkOrder{"O-1", "C-2"}))
+	assert.ErrorIs(t, stream.Flush(), ErrLinkTarget)
+
+	// NOTE: The pending links of the replaced sheet are dropped.
+	orders, err := NewSheet[linkOrder](f, "Orders", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, orders.SetHeader())
+	require.NoError(t, orders.SetRow(&linkOrder{"O-1", "C-2"}))
+	require.NoError(t, orders.Flush())
+	_, err = NewSheet[linkOrder](f, "Orders", "A1", true, WithOverwrite())
+	require.NoError(t, err)
+
+	require.NoError(t, customers.Flush())
+	require.NoError(t, stream.Flush())
+	ok, _, err := f.GetCellHyperLink("Orders", "B2")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	_, ok = f.lookupKey("Customer List", "C-1")
+	assert.False(t, ok)
+}
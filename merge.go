@@ -0,0 +1,149 @@
+package exceltable
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// mergeRepeatsOption is the option of the excel tag merging vertically consecutive cells with equal values.
+const mergeRepeatsOption string = "mergeRepeats"
+
+// cellMerger merges cells in a column.
+type cellMerger interface {
+	// mergeCells merges the cells of column col from row top to bottom.
+	mergeCells(col, top, bottom int) error
+}
+
+// repeatRun is a run of consecutive cells with equal values in a column with the "mergeRepeats" option.
+type repeatRun struct {
+	value string // formatted value of the cells
+	top   int    // first row of the run, or 0 if there is no run
+}
+
+// repeatRuns returns the run of each column with the "mergeRepeats" option, or nil for the other columns.
+func repeatRuns(sc *schema) []*repeatRun {
+	runs := make([]*repeatRun, sc.tableWidth)
+	for col, i := range sc.fields {
+		if sc.formulas[col] == "" && hasTagOption(sc.typ.Field(i), excelTag, mergeRepeatsOption) {
+			runs[col] = &repeatRun{}
+		}
+	}
+	return runs
+}
+
+// concealedNumFmt is the number format hiding the value of the cell.
+const concealedNumFmt = ";;;"
+
+// mergeRange is a range of cells of a column to be merged.
+type mergeRange struct {
+	col, top, bottom int
+}
+
+// trackRepeats extends the runs with the values of v written in the current row,
+// and records the cells of the runs ended to be merged.
+func (s *sheetBase[M]) trackRepeats(v reflect.Value) {
+	for col, run := range s.repeats {
+		if run == nil {
+			continue
+		}
+
+		value := formatValue(v.Field(s.fields[col]))
+		if run.top > 0 && run.value == value {
+			continue
+		}
+		s.endRun(col)
+		if value != "" { // NOTE: Empty cells are not merged.
+			run.value, run.top = value, s.row
+		}
+	}
+}
+
+// endRuns ends the runs of all columns before the current row.
+func (s *sheetBase[M]) endRuns() {
+	for col := range s.repeats {
+		s.endRun(col)
+	}
+}
+
+// endRun ends the run of column col before the current row, recording its cells to be merged if it has two or more rows.
+func (s *sheetBase[M]) endRun(col int) {
+	run := s.repeats[col]
+	if run == nil || run.top == 0 {
+		return
+	}
+
+	top, bottom := run.top, s.row-1
+	run.value, run.top = "", 0
+	if bottom > top {
+		s.merges = append(s.merges, mergeRange{col, top, bottom})
+	}
+}
+
+// mergeRepeats merges the cells of the runs by m, unless a table is added.
+//
+// NOTE: Excel does not allow merged cells in tables, so the repeated values are only hidden by repeatStyle.
+func (s *sheetBase[M]) mergeRepeats(m cellMerger) error {
+	s.endRuns()
+	if !s.hasTable {
+		for _, r := range s.merges {
+			if err := m.mergeCells(r.col, r.top, r.bottom); err != nil {
+				return err
+			}
+		}
+	}
+	s.merges = nil
+	return nil
+}
+
+// repeatStyle returns the style ID of the data cell of column col with styleID (0 if none),
+// which hides the value if it repeats the value above in the run of the "mergeRepeats" option,
+// so that the cells look merged even in tables.
+func (s *sheetBase[M]) repeatStyle(col int, field reflect.Value, styleID int) (int, error) {
+	run := s.repeats[col]
+	if run == nil || run.top == 0 || run.value != formatValue(field) {
+		return styleID, nil
+	}
+	return s.File.concealedStyle(styleID)
+}
+
+// concealedStyle returns the ID of the style which is the same as styleID but hides the value.
+// The style is created at the first call and reused afterwards.
+func (f *File) concealedStyle(styleID int) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if id, ok := f.concealed[styleID]; ok {
+		return id, nil
+	}
+
+	style := &excelize.Style{}
+	if styleID != 0 {
+		var err error
+		if style, err = f.GetStyle(styleID); err != nil {
+			return 0, err
+		}
+	}
+	numFmt := concealedNumFmt
+	style.NumFmt, style.CustomNumFmt = 0, &numFmt
+
+	id, err := f.NewStyle(style)
+	if err != nil {
+		return 0, err
+	}
+	if f.concealed == nil {
+		f.concealed = make(map[int]int)
+	}
+	f.concealed[styleID] = id
+	return id, nil
+}
+
+// checkMerges returns ErrMergedCells if key cells of groups are merged, since Excel does not allow
+// merged cells in tables and repairs such files on open.
+func (s *sheetBase[M]) checkMerges() error {
+	if s.mergedKeys {
+		return fmt.Errorf("%w: key cells of groups are merged", ErrMergedCells)
+	}
+	return nil
+}
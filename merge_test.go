@@ -0,0 +1,120 @@
+package exceltable
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type order struct {
+	Customer string `excel:"顧客,mergeRepeats"`
+	ID       string `excel:"注文"`
+	Amount   int    `excel:"金額,mergeRepeats"`
+}
+
+var orders = []*order{
+	{"Alice", "O-1", 100},
+	{"Alice", "O-2", 100},
+	{"Bob", "O-3", 100},
+	{"Bob", "O-4", 200},
+	{"Bob", "O-5", 300},
+	{"", "O-6", 300},
+	{"", "O-7", 300},
+	{"Carol", "O-8", 400},
+}
+
+func mergedRanges(t *testing.T, f *File, sheet string) []string {
+	t.Helper()
+
+	cells, err := f.GetMergeCells(sheet)
+	require.NoError(t, err)
+	ranges := make([]string, 0, len(cells))
+	for _, c := range cells {
+		ranges = append(ranges, c.GetStartAxis()+":"+c.GetEndAxis())
+	}
+	return ranges
+}
+
+func TestSheet_MergeRepeats(t *testing.T) {
+	type rowWriter interface {
+		SetHeader() error
+		SetRow(obj *order) error
+		AddDefaultTable() error
+		Flush() error
+	}
+	writers := []struct {
+		name string
+		new  func(f *File) (rowWriter, error)
+	}{
+		{"Sheet", func(f *File) (rowWriter, error) { return NewSheet[order](f, "Orders", "A1", true) }},
+		{"SheetWithStreamWriter", func(f *File) (rowWriter, error) { return NewSheetWithStreamWriter[order](f, "Orders", "A1", true) }},
+	}
+	for _, w := range writers {
+		for _, table := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/table=%t", w.name, table), func(t *testing.T) {
+				f, err := NewFile()
+				require.NoError(t, err)
+				s, err := w.new(f)
+				require.NoError(t, err)
+				require.NoError(t, s.SetHeader())
+				for _, o := range orders {
+					require.NoError(t, s.SetRow(o))
+				}
+				if table {
+					require.NoError(t, s.AddDefaultTable())
+				}
+				require.NoError(t, s.Flush())
+
+				path := filepath.Join(t.TempDir(), "test.xlsx")
+				require.NoError(t, f.SaveAs(path))
+				saved, err := OpenFile(path)
+				require.NoError(t, err)
+
+				tables, err := saved.GetTables("Orders")
+				require.NoError(t, err)
+				if table {
+					// NOTE: Tables cannot contain merged cells, so the repeated values are hidden instead.
+					assert.Empty(t, mergedRanges(t, saved, "Orders"))
+					require.Len(t, tables, 1)
+					assert.Equal(t, "A1:C9", tables[0].Range)
+					for cell, concealed := range map[string]bool{"A2": false, "A3": true, "A5": true, "B3": false, "C3": true, "C5": false, "A7": false} {
+						styleID, err := saved.GetCellStyle("Orders", cell)
+						require.NoError(t, err)
+						style, err := saved.GetStyle(styleID)
+						require.NoError(t, err)
+						assert.Equal(t, concealed, style.CustomNumFmt != nil && *style.CustomNumFmt == concealedNumFmt, cell)
+					}
+				} else {
+					assert.ElementsMatch(t, []string{"A2:A3", "A4:A6", "C2:C4", "C6:C8"}, mergedRanges(t, saved, "Orders"))
+					assert.Empty(t, tables)
+				}
+
+				read, err := ReadRows[order](saved, "Orders", "A1")
+				require.NoError(t, err)
+				assert.Equal(t, orders, read)
+			})
+		}
+	}
+}
+
+func TestSheet_MergeRepeatsInGroups(t *testing.T) {
+	type item struct {
+		Category string `excel:"Category"`
+		Customer string `excel:"Customer,mergeRepeats"`
+	}
+
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	s, err := NewSheet[item](f, "Items", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	require.NoError(t, s.SetGroupedRows([]*item{{"X", "Alice"}, {"X", "Alice"}, {"Y", "Alice"}}, "Category"))
+	require.NoError(t, s.Flush())
+
+	// NOTE: Subtotal rows end runs.
+	assert.Equal(t, []string{"B2:B3"}, mergedRanges(t, f, "Items"))
+}
@@ -0,0 +1,213 @@
+package exceltable
+
+import (
+	"fmt"
+	"html"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxTableNameLength is the maximum number of characters of table names.
+const maxTableNameLength = 255
+
+// placeholderSheetName is the name of the sheet created temporarily to overwrite the only sheet.
+const placeholderSheetName = "exceltable.placeholder"
+
+var (
+	// cellRefLikePattern matches names which Excel confuses with cell references, such as "A1" and "R1C1".
+	cellRefLikePattern = regexp.MustCompile(`(?i)^([a-z]{1,3}[0-9]+|r[0-9]*c[0-9]*|r|c)$`)
+
+	// tableNamePattern matches the names of table parts.
+	tableNamePattern = regexp.MustCompile(`<table [^>]*?\bname="([^"]*)"`)
+)
+
+// sanitizeTableName converts name into a valid table name, replacing invalid characters with "_".
+func sanitizeTableName(name string) string {
+	var sb strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r), r == '_':
+			sb.WriteRune(r)
+		case '0' <= r && r <= '9', r == '.':
+			if sb.Len() == 0 {
+				sb.WriteRune('_') // NOTE: Table names must not start with a digit or a period.
+			}
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+
+	s := sb.String()
+	if s == "" || cellRefLikePattern.MatchString(s) {
+		s = "_" + s
+	}
+	if utf8.RuneCountInString(s) > maxTableNameLength {
+		s = string([]rune(s)[:maxTableNameLength])
+	}
+	return s
+}
+
+// isValidTableName reports whether name is a valid table name.
+func isValidTableName(name string) bool {
+	return name != "" && sanitizeTableName(name) == name
+}
+
+// prepareSheet creates the sheet name, or replaces the existing one if overwrite is true,
+// and reserves the name of its table by reserveTableName if tableName is given, which may be empty.
+// It returns ErrSheetExists if the sheet exists and overwrite is false.
+// The table name is released if it fails.
+func (f *File) prepareSheet(name string, overwrite bool, tableName ...string) (idx int, table string, err error) {
+	defer func() {
+		if err != nil && table != "" {
+			f.releaseTableName(table)
+		}
+	}()
+
+	if idx, err = f.GetSheetIndex(name); err != nil {
+		return 0, "", err
+	}
+	if idx == -1 {
+		if len(tableName) > 0 {
+			if table, err = f.reserveTableName(name, tableName[0], nil); err != nil {
+				return 0, "", err
+			}
+		}
+		if idx, err = f.NewSheet(name); err != nil {
+			return 0, table, err
+		}
+		return idx, table, nil
+	}
+	if !overwrite {
+		return 0, "", fmt.Errorf("%w: %q", ErrSheetExists, name)
+	}
+
+	// NOTE: The tables of the replaced sheet are deleted together, so their names can be reused.
+	tables, err := f.GetTables(name)
+	if err != nil {
+		return 0, "", err
+	}
+	replaced := make([]string, 0, len(tables))
+	for _, t := range tables {
+		replaced = append(replaced, t.Name)
+	}
+	f.releaseSheet(name)
+	if len(tableName) > 0 {
+		if table, err = f.reserveTableName(name, tableName[0], replaced); err != nil {
+			return 0, "", err
+		}
+	}
+
+	placeholder := f.SheetCount == 1 // NOTE: excelize.File.DeleteSheet does nothing when only one sheet is left.
+	if placeholder {
+		if _, err := f.NewSheet(placeholderSheetName); err != nil {
+			return 0, table, err
+		}
+	}
+	for _, t := range replaced {
+		if err := f.DeleteTable(t); err != nil {
+			return 0, table, err
+		}
+	}
+	if err := f.DeleteSheet(name); err != nil {
+		return 0, table, err
+	}
+	if _, err := f.NewSheet(name); err != nil {
+		return 0, table, err
+	}
+	if placeholder {
+		if err := f.DeleteSheet(placeholderSheetName); err != nil {
+			return 0, table, err
+		}
+	}
+
+	if idx, err = f.GetSheetIndex(name); err != nil {
+		return 0, table, err
+	}
+	return idx, table, nil
+}
+
+// reserveTableName reserves a table name unique in the workbook, which is case-insensitive,
+// regarding the names in ignore as unused.
+//
+// If name is empty, the name is derived from sheet, such as "Sales_2024Table" for "Sales 2024",
+// with a numeric suffix on collision. Otherwise, name is used as is.
+func (f *File) reserveTableName(sheet, name string, ignore []string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	used := make(map[string]bool)
+	for k := range f.tableNames {
+		used[k] = true
+	}
+	f.Pkg.Range(func(k, v any) bool {
+		if strings.HasPrefix(k.(string), "xl/tables/table") {
+			if m := tableNamePattern.FindSubmatch(v.([]byte)); m != nil {
+				used[strings.ToLower(html.UnescapeString(string(m[1])))] = true
+			}
+		}
+		return true
+	})
+	for _, dn := range f.GetDefinedName() {
+		used[strings.ToLower(dn.Name)] = true
+	}
+	for _, k := range ignore {
+		delete(used, strings.ToLower(k))
+	}
+
+	if name != "" {
+		if !isValidTableName(name) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidTableName, name)
+		}
+		if used[strings.ToLower(name)] {
+			return "", fmt.Errorf("%w: %q", ErrTableExists, name)
+		}
+	} else {
+		base := sanitizeTableName(sheet + "Table")
+		name = base
+		for i := 2; used[strings.ToLower(name)]; i++ {
+			suffix := fmt.Sprintf("_%d", i)
+			name = string([]rune(base)[:min(utf8.RuneCountInString(base), maxTableNameLength-len(suffix))]) + suffix
+		}
+	}
+
+	if f.tableNames == nil {
+		f.tableNames = make(map[string]string)
+	}
+	f.tableNames[strings.ToLower(name)] = sheet
+	return name, nil
+}
+
+// releaseTableName unregisters the table name reserved by reserveTableName.
+func (f *File) releaseTableName(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.tableNames, strings.ToLower(name))
+}
+
+// releaseSheet unregisters the table names reserved by, the counters, the keys, the flushed state
+// and the pending links of the sheet name.
+// The links to the sheet are kept, since they are set when the sheet replacing it is flushed.
+func (f *File) releaseSheet(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	maps.DeleteFunc(f.tableNames, func(_, sheet string) bool {
+		return strings.EqualFold(sheet, name)
+	})
+	f.stats = slices.DeleteFunc(f.stats, func(st *sheetStats) bool {
+		return strings.EqualFold(st.name, name)
+	})
+	delete(f.keys, strings.ToLower(name))
+	delete(f.flushed, strings.ToLower(name))
+	for sheet, links := range f.links {
+		f.links[sheet] = slices.DeleteFunc(links, func(l pendingLink) bool {
+			return strings.EqualFold(l.from, name)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package exceltable
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sanitizeTableName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"testTable", "testTable"},
+		{"Sales 2024Table", "Sales_2024Table"},
+		{"売上-集計Table", "売上_集計Table"},
+		{"2024Table", "_2024Table"},
+		{".hidden", "_.hidden"},
+		{"A1", "_A1"},
+		{"r1c1", "_r1c1"},
+		{"C", "_C"},
+		{"", "_"},
+		{strings.Repeat("a", 300), strings.Repeat("a", 255)},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, sanitizeTableName(tt.name), tt.name)
+	}
+}
+
+func TestNewSheet_TableName(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		sheet string
+		opts  []SheetOption
+		want  string
+	}{
+		{"Sales 2024", nil, "Sales_2024Table"},
+		{"Sales-2024", nil, "Sales_2024Table_2"},
+		{"売上", nil, "売上Table"},
+		{"2024", nil, "_2024Table"},
+		{"Explicit", []SheetOption{WithTableName("Persons")}, "Persons"},
+	} {
+		s, err := NewSheet[person](f, tt.sheet, "A1", true, tt.opts...)
+		require.NoError(t, err)
+		require.NoError(t, s.SetHeader())
+		require.NoError(t, s.SetRow(persons[0]))
+		require.NoError(t, s.AddDefaultTable(), tt.sheet)
+
+		tables, err := f.GetTables(tt.sheet)
+		require.NoError(t, err)
+		require.Len(t, tables, 1)
+		assert.Equal(t, tt.want, tables[0].Name)
+	}
+
+	_, err = NewSheet[person](f, "Another", "A1", true, WithTableName("persons"))
+	assert.ErrorIs(t, err, ErrTableExists)
+	_, err = NewSheet[person](f, "Another", "A1", true, WithTableName("1st table"))
+	assert.ErrorIs(t, err, ErrInvalidTableName)
+}
+
+func TestNewSheetWithStreamWriter_TableNameCollision(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	s1, err := NewSheetWithStreamWriter[person](f, "a b", "A1", true)
+	require.NoError(t, err)
+	s2, err := NewSheetWithStreamWriter[person](f, "a-b", "A1", true)
+	require.NoError(t, err)
+	for _, s := range []*SheetWithStreamWriter[person]{s1, s2} {
+		require.NoError(t, s.SetHeader())
+		require.NoError(t, s.SetRow(persons[0]))
+		require.NoError(t, s.AddDefaultTable())
+		require.NoError(t, s.Flush())
+	}
+	assert.Equal(t, "a_bTable", s1.tableName())
+	assert.Equal(t, "a_bTable_2", s2.tableName())
+}
+
+func TestNewSheet_SheetExists(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	_, err = NewSheet[person](f, "Sheet1", "A1", true)
+	assert.ErrorIs(t, err, ErrSheetExists)
+	_, err = NewSheetWithStreamWriter[person](f, "sheet1", "A1", true)
+	assert.ErrorIs(t, err, ErrSheetExists)
+
+	require.NoError(t, f.SetCellValue("Sheet1", "Z9", "stale"))
+	s, err := NewSheet[person](f, "Sheet1", "A1", true, WithOverwrite())
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	assert.Equal(t, []string{"Sheet1"}, f.GetSheetList())
+	stale, err := f.GetCellValue("Sheet1", "Z9")
+	require.NoError(t, err)
+	assert.Empty(t, stale)
+
+	_, err = NewSheet[person](f, "Other", "A1", false)
+	require.NoError(t, err)
+	_, err = NewSheetWithStreamWriter[person](f, "Sheet1", "A1", true, WithOverwrite())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Other", "Sheet1"}, f.GetSheetList())
+	assert.Len(t, f.stats, 2)
+}
+
+func TestNewSheet_OverwriteTable(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	for range 2 {
+		s, err := NewSheet[person](f, "Orders", "A1", true, WithOverwrite())
+		require.NoError(t, err)
+		require.NoError(t, s.SetHeader())
+		require.NoError(t, s.SetRow(persons[0]))
+		require.NoError(t, s.AddDefaultTable())
+		assert.Equal(t, "OrdersTable", s.tableName())
+	}
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	saved, err := OpenFile(path)
+	require.NoError(t, err)
+	tables, err := saved.GetTables("Orders")
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	assert.Equal(t, "OrdersTable", tables[0].Name)
+}
+
+func TestNewSheet_InvalidTagsLeaveNothing(t *testing.T) {
+	type invalidFormula struct {
+		Qty int      `excel:"Qty"`
+		_   struct{} `excel:"Total" excelformula:"=[@Qty]*[@Price]"`
+	}
+	type invalidImage struct {
+		Photo int `image:""`
+	}
+
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	_, err = NewSheet[invalidFormula](f, "Orders", "A1", true)
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+	_, err = NewSheetWithStreamWriter[invalidImage](f, "Orders", "A1", true)
+	assert.ErrorIs(t, err, ErrInvalidImage)
+	assert.Equal(t, []string{"Sheet1"}, f.GetSheetList())
+	assert.Empty(t, f.tableNames)
+	assert.Empty(t, f.stats)
+
+	s, err := NewSheet[person](f, "Orders", "A1", true)
+	require.NoError(t, err)
+	assert.Equal(t, "OrdersTable", s.tableName())
+}
@@ -0,0 +1,103 @@
+package exceltable
+
+import "github.com/xuri/excelize/v2"
+
+// SheetOption configures Sheet and SheetWithStreamWriter.
+type SheetOption func(*sheetOptions)
+
+type sheetOptions struct {
+	ruleComments bool
+	protection   *excelize.SheetProtectionOptions
+	freezeHeader bool
+	autoFilter   bool
+	autoWidth    *autoWidth
+	tableName    string
+	overwrite    bool
+}
+
+// autoWidth is the range of column widths set by WithAutoWidth.
+type autoWidth struct {
+	min, max float64
+}
+
+func newSheetOptions(opts []SheetOption) *sheetOptions {
+	o := &sheetOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithRuleComments attaches a comment to each highlighted cell, which explains the rule tag and
+// the predicate that matched, e.g. "warn: IsChild". Descriptions registered by
+// RegisterPredicateWithDescription or RegisterDescription are shown instead of predicate keys.
+//
+// For SheetWithStreamWriter, comments are added when Flush is called, after all rows are written.
+func WithRuleComments() SheetOption {
+	return func(o *sheetOptions) {
+		o.ruleComments = true
+	}
+}
+
+// WithSheetProtection protects the sheet with opts, such as password and allowed actions,
+// when Flush is called. Cells are locked unless their columns are editable:
+//
+//	type YourStruct struct {
+//		ID   string `excel:"ID,locked"`     // locked, and the other columns become editable.
+//		Memo string `excel:"Memo,editable"` // editable.
+//	}
+//
+// If no column has the "locked" option, columns without the "editable" option are locked.
+func WithSheetProtection(opts *excelize.SheetProtectionOptions) SheetOption {
+	return func(o *sheetOptions) {
+		o.protection = opts
+	}
+}
+
+// WithFreezeHeader freezes the rows down to the header row, so that the header stays visible while scrolling.
+func WithFreezeHeader() SheetOption {
+	return func(o *sheetOptions) {
+		o.freezeHeader = true
+	}
+}
+
+// WithAutoFilter turns on the autofilter over the header and data rows when Flush is called.
+// It is ignored if a table is added, since tables have their own filters.
+func WithAutoFilter() SheetOption {
+	return func(o *sheetOptions) {
+		o.autoFilter = true
+	}
+}
+
+// WithAutoWidth sizes each column to fit its header and values, within the range [min, max],
+// counting East Asian wide characters as two characters.
+//
+// For Sheet, widths are set when Flush is called. For SheetWithStreamWriter, which must set widths
+// before writing rows, the header and the first rows are held back and used to compute widths.
+func WithAutoWidth(min, max float64) SheetOption {
+	return func(o *sheetOptions) {
+		o.autoWidth = &autoWidth{min, max}
+	}
+}
+
+// WithTableName sets the name of the table created by AddTable.
+// By default, the name is derived from the sheet name, such as "Sales_2024Table" for "Sales 2024",
+// and made unique in the workbook.
+//
+// NewSheet and NewSheetWithStreamWriter return ErrInvalidTableName if name is not a valid table name,
+// and ErrTableExists if the name is already used in the workbook.
+func WithTableName(name string) SheetOption {
+	return func(o *sheetOptions) {
+		o.tableName = name
+	}
+}
+
+// WithOverwrite replaces the sheet if it already exists.
+// Without it, NewSheet and NewSheetWithStreamWriter return ErrSheetExists.
+//
+// The replaced sheet is moved to the end of the workbook.
+func WithOverwrite() SheetOption {
+	return func(o *sheetOptions) {
+		o.overwrite = true
+	}
+}
@@ -0,0 +1,147 @@
+package exceltable
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// defaultPivotTableStyle is the style of pivot tables created by AddPivotTable unless specified.
+const defaultPivotTableStyle = "PivotStyleLight16"
+
+// PivotTable describes the pivot table created by AddPivotTable.
+// Fields are referred to by struct field name or header value.
+type PivotTable struct {
+	Sheet     string      // sheet to place the pivot table on, which is created if it does not exist
+	Cell      string      // top-left cell of the pivot table, "A1" if empty
+	Rows      []string    // row fields
+	Columns   []string    // column fields
+	Filter    []string    // filter fields
+	Data      []PivotData // data fields
+	StyleName string      // pivot table style name, "PivotStyleLight16" if empty
+}
+
+// PivotData is a data field of PivotTable.
+type PivotData struct {
+	Field    string // struct field name or header value
+	Subtotal string // aggregation function, such as "Sum", "Count" and "Average", "Sum" if empty
+	Name     string // display name, such as "Sum of Revenue" if empty
+}
+
+// AddPivotTable creates a pivot table summarizing the data rows written so far, without the totals row.
+// The pivot table is refreshed when the spreadsheet is opened.
+//
+//	_ = s.AddPivotTable(&exceltable.PivotTable{
+//		Sheet: "Pivot",
+//		Rows:  []string{"Region"},
+//		Data:  []exceltable.PivotData{{Field: "Revenue", Subtotal: "Sum"}},
+//	})
+//
+// It returns ErrUnknownColumn if any field is not found. The sheet created for the pivot table is deleted on error.
+func (s *sheetBase[M]) AddPivotTable(pt *PivotTable) error {
+	opts, err := s.pivotTableOptions(pt)
+	if err != nil {
+		return err
+	}
+
+	idx, err := s.File.GetSheetIndex(pt.Sheet)
+	if err != nil {
+		return err
+	}
+	if idx == -1 {
+		if _, err := s.File.NewSheet(pt.Sheet); err != nil {
+			return err
+		}
+	}
+	if err := s.File.AddPivotTable(opts); err != nil {
+		if idx == -1 {
+			_ = s.File.DeleteSheet(pt.Sheet) // NOTE: The error of AddPivotTable is more relevant.
+		}
+		return err
+	}
+	return nil
+}
+
+// AddPivotTable creates a pivot table summarizing the data rows written so far, without the totals row.
+// See Sheet.AddPivotTable for details.
+//
+// It must be called before Flush.
+func (ssw *SheetWithStreamWriter[M]) AddPivotTable(pt *PivotTable) error {
+	// NOTE: excelize.File.AddPivotTable reads the field names from the header cells,
+	// which excelize.StreamWriter does not keep. The copy is not saved, since the stream replaces the sheet data.
+	if err := ssw.File.SetSheetRow(ssw.name, ssw.coordinatesToCellName(0, 0), &ssw.header); err != nil {
+		return err
+	}
+	return ssw.sheetBase.AddPivotTable(pt)
+}
+
+// pivotTableOptions builds excelize.PivotTableOptions from pt.
+func (s *sheetBase[M]) pivotTableOptions(pt *PivotTable) (*excelize.PivotTableOptions, error) {
+	fields := func(names []string) ([]excelize.PivotTableField, error) {
+		ptf := make([]excelize.PivotTableField, 0, len(names))
+		for _, name := range names {
+			col, ok := s.columnByHeader(name)
+			if !ok {
+				return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, name)
+			}
+			ptf = append(ptf, excelize.PivotTableField{Data: s.header[col].(string)})
+		}
+		return ptf, nil
+	}
+
+	rows, err := fields(pt.Rows)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := fields(pt.Columns)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := fields(pt.Filter)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]excelize.PivotTableField, 0, len(pt.Data))
+	for _, d := range pt.Data {
+		col, ok := s.columnByHeader(d.Field)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, d.Field)
+		}
+		h := s.header[col].(string)
+		subtotal := cmp.Or(d.Subtotal, "Sum")
+		data = append(data, excelize.PivotTableField{
+			Data:     h,
+			Subtotal: subtotal,
+			Name:     cmp.Or(d.Name, fmt.Sprintf("%s of %s", subtotal, h)),
+		})
+	}
+
+	x, y, err := excelize.CellNameToCoordinates(cmp.Or(pt.Cell, "A1"))
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: The range of the pivot table is adjusted when it is refreshed.
+	bottomRight, err := excelize.CoordinatesToCellName(x+max(len(rows)+len(data)-1, 1), y+1)
+	if err != nil {
+		return nil, err
+	}
+	topLeft, _ := excelize.CoordinatesToCellName(x, y)
+
+	dataRange := fmt.Sprintf("%s:%s", s.coordinatesToCellName(0, 0), s.coordinatesToCellName(s.tableWidth-1, max(s.row-1, 1)))
+	return &excelize.PivotTableOptions{
+		DataRange:           s.name + "!" + dataRange, // NOTE: excelize does not accept quoted sheet names.
+		PivotTableRange:     fmt.Sprintf("%s!%s:%s", pt.Sheet, topLeft, bottomRight),
+		Rows:                rows,
+		Columns:             columns,
+		Filter:              filter,
+		Data:                data,
+		RowGrandTotals:      true,
+		ColGrandTotals:      true,
+		ShowDrill:           true,
+		ShowRowHeaders:      true,
+		ShowColHeaders:      true,
+		ShowLastColumn:      true,
+		PivotTableStyleName: cmp.Or(pt.StyleName, defaultPivotTableStyle),
+	}, nil
+}
@@ -0,0 +1,91 @@
+package exceltable
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSheet_AddPivotTable(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	s, err := NewSheet[person](f, "Persons", "B2", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	for _, p := range persons {
+		require.NoError(t, s.SetRow(p))
+	}
+	require.NoError(t, s.AddDefaultTable())
+
+	require.NoError(t, s.AddPivotTable(&PivotTable{
+		Sheet: "Pivot",
+		Rows:  []string{"Address"},
+		Data:  []PivotData{{Field: "Age", Subtotal: "Average"}, {Field: "ID", Subtotal: "Count", Name: "Persons"}},
+	}))
+	assert.Contains(t, f.GetSheetList(), "Pivot")
+
+	pivots, err := f.GetPivotTables("Pivot")
+	require.NoError(t, err)
+	require.Len(t, pivots, 1)
+	assert.Equal(t, "Persons!B2:F5", pivots[0].DataRange)
+	require.Len(t, pivots[0].Rows, 1)
+	assert.Equal(t, "住所", pivots[0].Rows[0].Data)
+	require.Len(t, pivots[0].Data, 2)
+	assert.Equal(t, "Average of 年齢", pivots[0].Data[0].Name)
+	assert.Equal(t, "Persons", pivots[0].Data[1].Name)
+
+	// The pivot table can be placed on an existing sheet.
+	require.NoError(t, s.AddPivotTable(&PivotTable{
+		Sheet:   "Sheet1",
+		Cell:    "C3",
+		Columns: []string{"氏名"},
+		Data:    []PivotData{{Field: "年齢"}},
+	}))
+	pivots, err = f.GetPivotTables("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, pivots, 1)
+	assert.Equal(t, "Sum of 年齢", pivots[0].Data[0].Name)
+
+	err = s.AddPivotTable(&PivotTable{Sheet: "Pivot", Rows: []string{"Region"}})
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+
+	// The sheet created for the pivot table is deleted on error.
+	err = s.AddPivotTable(&PivotTable{Sheet: "Pivot!2", Rows: []string{"Address"}, Data: []PivotData{{Field: "Age"}}})
+	assert.Error(t, err)
+	assert.NotContains(t, f.GetSheetList(), "Pivot!2")
+}
+
+func TestSheetWithStreamWriter_AddPivotTable(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	ssw, err := NewSheetWithStreamWriter[person](f, "Persons", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, ssw.SetHeader())
+	for _, p := range persons {
+		require.NoError(t, ssw.SetRow(p))
+	}
+	require.NoError(t, ssw.AddPivotTable(&PivotTable{
+		Sheet: "Pivot",
+		Rows:  []string{"Name"},
+		Data:  []PivotData{{Field: "Age"}},
+	}))
+	require.NoError(t, ssw.Flush())
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	saved, err := OpenFile(path)
+	require.NoError(t, err)
+
+	pivots, err := saved.GetPivotTables("Pivot")
+	require.NoError(t, err)
+	require.Len(t, pivots, 1)
+	assert.Equal(t, "Persons!A1:E4", pivots[0].DataRange)
+
+	rows, err := saved.GetRows("Persons")
+	require.NoError(t, err)
+	assert.Len(t, rows, 4)
+}
@@ -0,0 +1,78 @@
+package exceltable
+
+import "github.com/xuri/excelize/v2"
+
+// Options of the excel tag indicating cell protection.
+const (
+	lockedOption   string = "locked"
+	editableOption string = "editable"
+)
+
+// editableColumns reports whether each column of sc is editable on a protected sheet.
+//
+// Columns with the "editable" option are editable. If any column has the "locked" option,
+// columns without either option are editable as well.
+func editableColumns(sc *schema) []bool {
+	locked := make([]bool, sc.tableWidth)
+	editable := make([]bool, sc.tableWidth)
+	anyLocked := false
+	for col, i := range sc.fields {
+		field := sc.typ.Field(i)
+		locked[col] = hasTagOption(field, excelTag, lockedOption)
+		editable[col] = hasTagOption(field, excelTag, editableOption)
+		anyLocked = anyLocked || locked[col]
+	}
+
+	for col := range editable {
+		if anyLocked && !locked[col] {
+			editable[col] = true
+		}
+	}
+	return editable
+}
+
+// unlockedStyle returns the ID of the style which is the same as styleID but unlocked.
+// The style is created at the first call and reused afterwards.
+func (f *File) unlockedStyle(styleID int) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if id, ok := f.unlocked[styleID]; ok {
+		return id, nil
+	}
+
+	style := &excelize.Style{}
+	if styleID != 0 {
+		var err error
+		if style, err = f.GetStyle(styleID); err != nil {
+			return 0, err
+		}
+	}
+	style.Protection = &excelize.Protection{Locked: false, Hidden: style.Protection != nil && style.Protection.Hidden}
+
+	id, err := f.NewStyle(style)
+	if err != nil {
+		return 0, err
+	}
+	if f.unlocked == nil {
+		f.unlocked = make(map[int]int)
+	}
+	f.unlocked[styleID] = id
+	return id, nil
+}
+
+// cellStyle returns the style ID of the data cell of column col with the rule style styleID (0 if none).
+func (s *sheetBase[M]) cellStyle(col, styleID int) (int, error) {
+	if s.opts.protection == nil || !s.editable[col] {
+		return styleID, nil
+	}
+	return s.File.unlockedStyle(styleID)
+}
+
+// protect protects the sheet if WithSheetProtection is specified.
+func (s *sheetBase[M]) protect() error {
+	if s.opts.protection == nil {
+		return nil
+	}
+	return s.File.ProtectSheet(s.name, s.opts.protection)
+}
@@ -0,0 +1,113 @@
+package exceltable
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+type ticket struct {
+	ID     string `excel:"ID,locked"`
+	Title  string `excel:"Title"`
+	Status string `excel:",editable"`
+}
+
+type lockedTicket struct {
+	ID    string `excel:"ID"`
+	Title string `excel:"Title,editable"`
+}
+
+func TestEditableColumns(t *testing.T) {
+	sc, err := newDetachedSchema(reflect.TypeFor[ticket](), excelTag)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false, true, true}, editableColumns(sc))
+	assert.Equal(t, "Status", sc.header[2])
+
+	sc, err = newDetachedSchema(reflect.TypeFor[lockedTicket](), excelTag)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false, true}, editableColumns(sc))
+}
+
+func TestSheet_WithSheetProtection(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(f *File, opts ...SheetOption) error
+	}{
+		{"Sheet", func(f *File, opts ...SheetOption) error {
+			s, err := NewSheet[ticket](f, "test", "A1", true, opts...)
+			if err != nil {
+				return err
+			}
+			if err := s.SetHeader(); err != nil {
+				return err
+			}
+			if err := s.SetRow(&ticket{"T-1", "Broken", "open"}); err != nil {
+				return err
+			}
+			return s.Flush()
+		}},
+		{"SheetWithStreamWriter", func(f *File, opts ...SheetOption) error {
+			ssw, err := NewSheetWithStreamWriter[ticket](f, "test", "A1", true, opts...)
+			if err != nil {
+				return err
+			}
+			if err := ssw.SetHeader(); err != nil {
+				return err
+			}
+			if err := ssw.SetRow(&ticket{"T-1", "Broken", "open"}); err != nil {
+				return err
+			}
+			return ssw.Flush()
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFile()
+			require.NoError(t, err)
+			require.NoError(t, tt.write(f, WithSheetProtection(&excelize.SheetProtectionOptions{Password: "secret", SelectUnlockedCells: true})))
+
+			locked := func(cell string) bool {
+				styleID, err := f.GetCellStyle("test", cell)
+				require.NoError(t, err)
+				style, err := f.GetStyle(styleID)
+				require.NoError(t, err)
+				return style.Protection == nil || style.Protection.Locked
+			}
+			assert.True(t, locked("A1"))
+			assert.True(t, locked("A2"))
+			assert.False(t, locked("B2"))
+			assert.False(t, locked("C2"))
+
+			path := filepath.Join(t.TempDir(), "test.xlsx")
+			require.NoError(t, f.SaveAs(path))
+			saved, err := excelize.OpenFile(path)
+			require.NoError(t, err)
+			defer saved.Close()
+			assert.ErrorIs(t, saved.UnprotectSheet("test", "wrong"), excelize.ErrUnprotectSheetPassword)
+			assert.NoError(t, saved.UnprotectSheet("test", "secret"))
+		})
+	}
+}
+
+func TestFile_unlockedStyle(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	warn := fileRuleByTag(t, f, "warn")
+
+	id, err := f.unlockedStyle(warn.styleID)
+	require.NoError(t, err)
+	again, err := f.unlockedStyle(warn.styleID)
+	require.NoError(t, err)
+	assert.Equal(t, id, again)
+
+	style, err := f.GetStyle(id)
+	require.NoError(t, err)
+	original, err := f.GetStyle(warn.styleID)
+	require.NoError(t, err)
+	assert.Equal(t, original.Fill, style.Fill)
+	assert.False(t, style.Protection.Locked)
+}
@@ -0,0 +1,194 @@
+package exceltable
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ReadRows reads the table written by Sheet or SheetWithStreamWriter from the sheet,
+// whose header row starts at cell, and returns its data rows.
+//
+// Columns are mapped to fields of M by header, including hidden columns,
+// and unknown columns are ignored. Reading stops at the first empty row,
+// or at the totals row if the header row is that of a table written by AddTable.
+// Subtotal rows written by SetGroupedRows, whose outline level is lower than the data rows, are skipped.
+// Merged cells, such as those of the "mergeRepeats" option, are read as the value of their top-left cell,
+// and cells of nil pointers, written as "<nil>", are read as nil.
+//
+//	persons, _ := exceltable.ReadRows[Person](f, "NewSheet", "A1")
+func ReadRows[M any](f *File, name, cell string) ([]*M, error) {
+	sc, err := newDetachedSchema(reflect.TypeFor[M](), excelTag, csvTag)
+	if err != nil {
+		return nil, err
+	}
+
+	x, y, err := excelize.CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := f.GetWorkbookProps()
+	if err != nil {
+		return nil, err
+	}
+	date1904 := props.Date1904 != nil && *props.Date1904
+
+	rows, err := f.GetRows(name, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, err
+	}
+	if err := fillMergedCells(f, name, rows); err != nil {
+		return nil, err
+	}
+	if len(rows) < y {
+		return nil, ErrTableNotFound
+	}
+
+	header := rows[y-1]
+	cols := make([]int, len(header))
+	for i := range header {
+		cols[i] = -1
+		if i < x-1 {
+			continue
+		}
+		if col, ok := sc.columnByHeader(header[i]); ok {
+			cols[i] = col
+		}
+	}
+
+	data := rows[y:]
+	for i, row := range data {
+		if isEmptyRow(row, x-1) {
+			data = data[:i]
+			break
+		}
+	}
+	n, err := dataRowCount(f, name, x, y)
+	if err != nil {
+		return nil, err
+	}
+	if n >= 0 && len(data) > n {
+		data = data[:n] // NOTE: The totals row has values once Excel recalculates it.
+	}
+	levels, err := outlineLevels(f, name, y+1, len(data))
+	if err != nil {
+		return nil, err
+	}
+	maxLevel := slices.Max(append(levels, 0))
+
+	objs := make([]*M, 0, len(data))
+	for i, row := range data {
+		if levels[i] < maxLevel {
+			continue // NOTE: Subtotal rows are outside the groups of data rows.
+		}
+
+		obj := new(M)
+		v := reflect.ValueOf(obj).Elem()
+		for i, s := range row {
+			if i >= len(cols) || cols[i] < 0 {
+				continue
+			}
+			if err := parseCellValue(s, v.Field(sc.fields[cols[i]]), date1904); err != nil {
+				return nil, err
+			}
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+// outlineLevels returns the outline levels of n rows of the sheet name from row.
+func outlineLevels(f *File, name string, row, n int) ([]uint8, error) {
+	levels := make([]uint8, n)
+	for i := range levels {
+		level, err := f.GetRowOutlineLevel(name, row+i)
+		if err != nil {
+			return nil, err
+		}
+		levels[i] = level
+	}
+	return levels, nil
+}
+
+// fillMergedCells sets the value of the top-left cell of each merged range of the sheet name to
+// the other cells of the range in rows, since excelize.File.MergeCell clears them.
+func fillMergedCells(f *File, name string, rows [][]string) error {
+	cells, err := f.GetMergeCells(name)
+	if err != nil {
+		return err
+	}
+	for _, cell := range cells {
+		x1, y1, x2, y2, err := rangeRefToCoordinates(cell.GetStartAxis() + ":" + cell.GetEndAxis())
+		if err != nil {
+			return err
+		}
+		if y1 > len(rows) || x1 > len(rows[y1-1]) {
+			continue
+		}
+
+		value := rows[y1-1][x1-1]
+		for y := y1; y <= min(y2, len(rows)); y++ {
+			for len(rows[y-1]) < x2 {
+				rows[y-1] = append(rows[y-1], "")
+			}
+			for x := x1; x <= x2; x++ {
+				rows[y-1][x-1] = value
+			}
+		}
+	}
+	return nil
+}
+
+// isEmptyRow reports whether all cells of row from index begin are empty.
+func isEmptyRow(row []string, begin int) bool {
+	for i := begin; i < len(row); i++ {
+		if row[i] != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// nilCellValue is the cell value written for nil pointers, which excelize formats by fmt.Sprint.
+const nilCellValue = "<nil>"
+
+// parseCellValue parses the raw cell value s and stores the result in field.
+// Unlike parseValue, times and durations may be serial numbers of Excel,
+// and nilCellValue leaves pointer fields nil.
+func parseCellValue(s string, field reflect.Value, date1904 bool) error {
+	if s == nilCellValue && field.Kind() == reflect.Pointer {
+		return nil
+	}
+	t := field.Type()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType || t == durationType {
+		if serial, err := strconv.ParseFloat(s, 64); err == nil {
+			for field.Kind() == reflect.Pointer {
+				if field.IsNil() {
+					field.Set(reflect.New(field.Type().Elem()))
+				}
+				field = field.Elem()
+			}
+
+			if t == timeType {
+				tm, err := excelize.ExcelDateToTime(serial, date1904)
+				if err != nil {
+					return err
+				}
+				field.Set(reflect.ValueOf(tm))
+			} else {
+				d := time.Duration(serial * float64(24*time.Hour))
+				field.SetInt(int64(d.Round(time.Millisecond))) // NOTE: Serial numbers lose sub-millisecond precision.
+			}
+			return nil
+		}
+	}
+	return parseValue(s, field)
+}
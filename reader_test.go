@@ -0,0 +1,110 @@
+package exceltable
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type visit struct {
+	Name     string        `excel:"Name"`
+	Count    int           `excel:"Count"`
+	Member   bool          `excel:"Member"`
+	Date     time.Time     `excel:"Date"`
+	Duration time.Duration `excel:"Duration"`
+	Note     *string       `excel:"Note"`
+}
+
+func TestReadRows(t *testing.T) {
+	note := "VIP"
+	visits := []*visit{
+		{"Alice", 3, true, time.Date(2024, 4, 1, 9, 30, 0, 0, time.UTC), 90 * time.Minute, &note},
+		{"Bob", 1, false, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), 30 * time.Minute, nil},
+	}
+
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[visit](f, "test", "C3", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	for _, v := range visits {
+		require.NoError(t, s.SetRow(v))
+	}
+	require.NoError(t, s.Flush())
+	require.NoError(t, f.SetCellValue("test", "C7", "footer"))
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	f, err = OpenFile(path)
+	require.NoError(t, err)
+
+	got, err := ReadRows[visit](f, "test", "C3")
+	require.NoError(t, err)
+	assert.Equal(t, visits, got)
+
+	_, err = ReadRows[visit](f, "test", "C10")
+	assert.ErrorIs(t, err, ErrTableNotFound)
+}
+
+func TestReadRows_Persons(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[person](f, "test", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	for _, p := range persons {
+		require.NoError(t, s.SetRow(p))
+	}
+
+	nilValue, err := f.GetCellValue("test", "E3")
+	require.NoError(t, err)
+	assert.Equal(t, "<nil>", nilValue) // NOTE: Nil pointers are written as fmt.Sprint formats them.
+
+	got, err := ReadRows[person](f, "test", "A1")
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, "Carol", got[2].Name)
+	assert.Equal(t, 100, got[2].Age)
+	assert.Equal(t, "", got[2].AccountNumber) // omitted by `excel:"-"`.
+	assert.Nil(t, got[1].SpecialID)
+}
+
+type sale struct {
+	Item string `excel:"Item" total:"Total"`
+	Qty  int    `excel:"Qty" total:"sum"`
+}
+
+func TestReadRows_TotalsRow(t *testing.T) {
+	sales := []*sale{{"Pen", 3}, {"Notebook", 2}}
+
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[sale](f, "test", "B2", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	for _, v := range sales {
+		require.NoError(t, s.SetRow(v))
+	}
+	require.NoError(t, s.AddDefaultTable())
+	require.NoError(t, s.Flush())
+
+	// Excel caches the values of the totals row when it recalculates.
+	require.NoError(t, f.SetCellValue("test", "C5", 5))
+	require.NoError(t, f.SetCellFormula("test", "C5", "SUBTOTAL(109,TestTable[Qty])"))
+	require.NoError(t, f.SetCellValue("test", "B7", "footer"))
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	f, err = OpenFile(path)
+	require.NoError(t, err)
+	cached, err := f.GetCellValue("test", "C5")
+	require.NoError(t, err)
+	require.Equal(t, "5", cached)
+
+	got, err := ReadRows[sale](f, "test", "B2")
+	require.NoError(t, err)
+	assert.Equal(t, sales, got)
+}
@@ -0,0 +1,45 @@
+package exceltable
+
+import (
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// RichText is a field type written as a rich text cell, which has runs of text with different fonts:
+//
+//	type Item struct {
+//		Code exceltable.RichText `excel:"Code"`
+//	}
+//
+//	item := &Item{Code: exceltable.RichText{
+//		{Text: "A-1", Font: &excelize.Font{Bold: true}},
+//		{Text: " (discontinued)"},
+//	}}
+//
+// It is converted to the plain text for the other formats, such as CSV and JSON.
+type RichText []excelize.RichTextRun
+
+// String returns the plain text of rt.
+func (rt RichText) String() string {
+	var sb strings.Builder
+	for _, run := range rt {
+		sb.WriteString(run.Text)
+	}
+	return sb.String()
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the plain text of rt.
+func (rt RichText) MarshalText() ([]byte, error) {
+	return []byte(rt.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, setting rt to a single run of text without formatting.
+func (rt *RichText) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*rt = nil
+		return nil
+	}
+	*rt = RichText{{Text: string(text)}}
+	return nil
+}
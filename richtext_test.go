@@ -0,0 +1,89 @@
+package exceltable
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+type richItem struct {
+	Code RichText  `excel:"Code"`
+	Note *RichText `excel:"Note"`
+}
+
+var richItems = []*richItem{
+	{Code: RichText{{Text: "A-1", Font: &excelize.Font{Bold: true}}, {Text: " discontinued"}}},
+	{Code: RichText{{Text: "B-2"}}, Note: &RichText{{Text: "new", Font: &excelize.Font{Color: "FF0000"}}}},
+}
+
+func TestRichText(t *testing.T) {
+	rt := richItems[0].Code
+	assert.Equal(t, "A-1 discontinued", rt.String())
+	assert.Equal(t, "A-1 discontinued", formatValue(reflect.ValueOf(rt)))
+
+	var parsed RichText
+	require.NoError(t, parseValue("plain", reflect.ValueOf(&parsed).Elem()))
+	assert.Equal(t, RichText{{Text: "plain"}}, parsed)
+}
+
+func TestSheet_RichText(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	s, err := NewSheet[richItem](f, "Items", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	for _, item := range richItems {
+		require.NoError(t, s.SetRow(item))
+	}
+
+	runs, err := f.GetCellRichText("Items", "A2")
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "A-1", runs[0].Text)
+	assert.True(t, runs[0].Font.Bold)
+
+	runs, err = f.GetCellRichText("Items", "B3")
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "FF0000", runs[0].Font.Color)
+
+	v, err := f.GetCellValue("Items", "B2")
+	require.NoError(t, err)
+	assert.Equal(t, nilCellValue, v) // NOTE: Nil pointers are written as "<nil>" as with the other types.
+}
+
+func TestSheetWithStreamWriter_RichText(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	ssw, err := NewSheetWithStreamWriter[richItem](f, "Items", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, ssw.SetHeader())
+	for _, item := range richItems {
+		require.NoError(t, ssw.SetRow(item))
+	}
+	require.NoError(t, ssw.Flush())
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	saved, err := OpenFile(path)
+	require.NoError(t, err)
+
+	runs, err := saved.GetCellRichText("Items", "A2")
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.True(t, runs[0].Font.Bold)
+	assert.Equal(t, " discontinued", runs[1].Text)
+
+	rows, err := ReadRows[richItem](saved, "Items", "A1")
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "A-1 discontinued", rows[0].Code.String())
+	assert.Nil(t, rows[0].Note)
+	assert.Equal(t, "new", rows[1].Note.String())
+}
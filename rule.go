@@ -2,6 +2,7 @@ package exceltable
 
 import (
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -47,6 +48,10 @@ var rules = struct {
 // pair of (key, function).
 var predicates sync.Map
 
+// descriptions is a map of human-readable descriptions of predicates with key.
+// pair of (key, description).
+var descriptions sync.Map
+
 func init() {
 	RegisterRule(98, warnTag, &excelize.Style{
 		Fill: excelize.Fill{
@@ -111,6 +116,16 @@ func DeleteAllRules() {
 	rules.v = make([]*rule, 0)
 }
 
+// snapshotRules returns a copy of the registered rules in descending order of priority.
+func snapshotRules() []*rule {
+	rules.Lock()
+	defer rules.Unlock()
+
+	rs := slices.Clone(rules.v)
+	slices.Reverse(rs) // NOTE: Rules are sorted in ascending order of priority.
+	return rs
+}
+
 // RegisterPredicate registers a new predicate function with key:
 //
 //	exceltable.RegisterPredicate("isAlice", func(name string) bool {
@@ -120,9 +135,37 @@ func RegisterPredicate(key predKeyType, pred any) {
 	predicates.Store(key, pred)
 }
 
-// DeleteAllPredicates deletes all registered predicates.
+// RegisterPredicateWithDescription registers a new predicate function with key and its human-readable description,
+// which is shown in the comments attached by WithRuleComments:
+//
+//	exceltable.RegisterPredicateWithDescription("isAlice", "Alice is a new face", func(name string) bool {
+//		return name == "Alice"
+//	})
+func RegisterPredicateWithDescription(key predKeyType, description string, pred any) {
+	RegisterPredicate(key, pred)
+	RegisterDescription(key, description)
+}
+
+// RegisterDescription registers a human-readable description of the predicate with key.
+// It is useful to describe predicate methods:
+//
+//	exceltable.RegisterDescription("IsChild", "younger than 18")
+func RegisterDescription(key predKeyType, description string) {
+	descriptions.Store(key, description)
+}
+
+// DeleteAllPredicates deletes all registered predicates and their descriptions.
 func DeleteAllPredicates() {
 	predicates.Clear()
+	descriptions.Clear()
+}
+
+// describePredicate returns the description of the predicate with key, or key itself if there is none.
+func describePredicate(key predKeyType) string {
+	if description, ok := descriptions.Load(key); ok {
+		return description.(string)
+	}
+	return key
 }
 
 // CountByRule counts the number of fields in obj that satisfy the predicate associated with the rule tag.
@@ -16,6 +16,12 @@ func TestRegisterPredicate(t *testing.T) {
 	RegisterPredicate("tmp", func() bool { return true })
 }
 
+func TestRegisterPredicateWithDescription(t *testing.T) {
+	RegisterPredicateWithDescription("tmpDescribed", "temporary predicate", func() bool { return true })
+	assert.Equal(t, "temporary predicate", describePredicate("tmpDescribed"))
+	assert.Equal(t, "undescribed", describePredicate("undescribed"))
+}
+
 func TestCountByRule(t *testing.T) {
 	type args struct {
 		obj *person
@@ -0,0 +1,197 @@
+package exceltable
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// schema represents the column layout of struct type resolved from its struct tags.
+type schema struct {
+	typ        reflect.Type   // struct type
+	numField   int            // number of struct fields
+	tableWidth int            // table width (number of columns)
+	skip       []bool         // whether to skip each struct field
+	fields     []int          // struct field index of each column
+	header     []any          // header values
+	rulesList  [][]*sheetRule // rules for each column
+	formulas   []string       // formula of each column, or "" for value columns
+}
+
+// newSchema resolves the columns of struct type t.
+//
+// Header values are looked up in the order of headerTags, falling back to the field name.
+// Rules are compiled from fileRules, which must be in descending order of priority.
+func newSchema(t reflect.Type, fileRules []*fileRule, headerTags ...string) (*schema, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, ErrNotStructType
+	}
+	ptrT := reflect.PointerTo(t)
+
+	tableWidth, numField := 0, t.NumField()
+	skip := make([]bool, numField)
+	fields := make([]int, 0, numField)
+	header := make([]any, 0, numField)
+	rulesList := make([][]*sheetRule, 0, numField)
+	formulas := make([]string, 0, numField)
+	for i := range numField {
+		field := t.Field(i)
+		if _, ok := field.Tag.Lookup(excelFormulaTag); ok { // field is a marker of formula column.
+			skip[i] = true
+			continue
+		}
+		if field.PkgPath != "" { // field is unexported.
+			skip[i] = true
+			continue
+		}
+
+		h, ok := lookupHeader(field, headerTags...)
+		if !ok {
+			skip[i] = true
+			continue
+		}
+
+		rules, err := compileSheetRules(ptrT, field, fileRules)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, i)
+		header = append(header, h)
+		rulesList = append(rulesList, rules)
+		formulas = append(formulas, "")
+		tableWidth++
+	}
+
+	return &schema{
+		typ:        t,
+		numField:   numField,
+		tableWidth: tableWidth,
+		skip:       skip,
+		fields:     fields,
+		header:     header,
+		rulesList:  rulesList,
+		formulas:   formulas,
+	}, nil
+}
+
+// newDetachedSchema resolves the columns of struct type t with the registered rules,
+// for outputs which are not bound to a File.
+func newDetachedSchema(t reflect.Type, headerTags ...string) (*schema, error) {
+	rs := snapshotRules()
+	fileRules := make([]*fileRule, 0, len(rs))
+	for _, r := range rs {
+		fileRules = append(fileRules, &fileRule{tag: r.tag, priority: r.priority})
+	}
+	return newSchema(t, fileRules, headerTags...)
+}
+
+// tagOptions are the options recognized after commas in the values of header tags.
+// Other text after a comma is a part of the header value, such as `excel:"Amount, JPY"`.
+var tagOptions = map[string]bool{
+	hiddenOption:       true,
+	outlineOption:      true,
+	lockedOption:       true,
+	editableOption:     true,
+	mergeRepeatsOption: true,
+	keyOption:          true,
+	"omitempty":        true, // NOTE: Options of encoding/json.
+	"omitzero":         true,
+	"string":           true,
+}
+
+// splitTagValue splits the value of a header tag into the header value and the trailing options,
+// such as "ID" and ["key"] for `excel:"ID,key"`.
+func splitTagValue(value string) (string, []string) {
+	parts := strings.Split(value, ",")
+	n := len(parts)
+	for n > 1 && tagOptions[parts[n-1]] {
+		n--
+	}
+	return strings.Join(parts[:n], ","), parts[n:]
+}
+
+// lookupHeader returns the header value of field.
+// The boolean is false when the field is omitted by "-".
+//
+// Options following a comma in the tag value (e.g. `json:"name,omitempty"`) are ignored.
+func lookupHeader(field reflect.StructField, headerTags ...string) (string, bool) {
+	h := ""
+	for _, tag := range headerTags {
+		if h, _ = splitTagValue(field.Tag.Get(tag)); h != "" {
+			break
+		}
+	}
+
+	switch h {
+	case "":
+		return field.Name, true
+	case "-":
+		return "", false
+	}
+	return h, true
+}
+
+// hasTagOption reports whether the value of tag of field has option following the header value,
+// such as "locked" in `excel:"ID,locked"`.
+func hasTagOption(field reflect.StructField, tag, option string) bool {
+	_, opts := splitTagValue(field.Tag.Get(tag))
+	return slices.Contains(opts, option)
+}
+
+// compileSheetRules resolves the predicates written in the rule tags of field.
+func compileSheetRules(ptrT reflect.Type, field reflect.StructField, fileRules []*fileRule) ([]*sheetRule, error) {
+	rules := make([]*sheetRule, 0)
+	for _, rule := range fileRules {
+		for key := range strings.SplitSeq(field.Tag.Get(rule.tag), ",") {
+			switch key {
+			case "", "-":
+				// ignore
+			default:
+				if method, ok := ptrT.MethodByName(key); ok {
+					rules = append(rules, newSheetRule(method.Func, true, rule.tag, key, rule.styleID))
+					break
+				}
+
+				if function, ok := predicates.Load(key); ok {
+					rules = append(rules, newSheetRule(reflect.ValueOf(function), false, rule.tag, key, rule.styleID))
+					break
+				}
+
+				return nil, ErrUnknownPredicate
+			}
+		}
+	}
+	return rules, nil
+}
+
+// columnByHeader returns the column whose header value or field name equals name.
+func (s *schema) columnByHeader(name string) (int, bool) {
+	for col, h := range s.header {
+		if h == name {
+			return col, true
+		}
+	}
+	for col, i := range s.fields {
+		if s.typ.Field(i).Name == name {
+			return col, true
+		}
+	}
+	return 0, false
+}
+
+// matchRule returns the first rule of column col satisfied by field, or nil if there is none.
+func (s *schema) matchRule(col int, ptrV, field reflect.Value) (*sheetRule, error) {
+	for _, rule := range s.rulesList[col] {
+		pred := rule.bind(ptrV)
+		b, err := callPredicate(pred, field)
+		if err != nil {
+			return nil, err
+		}
+
+		if b {
+			return rule, nil // NOTE: Return the first match to prevent overwriting.
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,69 @@
+package exceltable
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_lookupHeader(t *testing.T) {
+	type fields struct {
+		Amount   int    `excel:"Amount, JPY"`
+		ID       string `excel:"ID,key,locked"`
+		Rate     int    `excel:"Rate, %,hidden"`
+		Name     string `json:"name,omitempty"`
+		Address  string `json:",omitempty"`
+		Omitted  string `excel:"-"`
+		NoHeader string
+	}
+	typ := reflect.TypeFor[fields]()
+
+	tests := []struct {
+		field  string
+		tags   []string
+		want   string
+		wantOK bool
+	}{
+		{"Amount", []string{excelTag}, "Amount, JPY", true},
+		{"ID", []string{excelTag}, "ID", true},
+		{"Rate", []string{excelTag}, "Rate, %", true},
+		{"Name", []string{jsonTag, excelTag}, "name", true},
+		{"Address", []string{jsonTag}, "Address", true},
+		{"Omitted", []string{excelTag}, "", false},
+		{"NoHeader", []string{excelTag}, "NoHeader", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			field, _ := typ.FieldByName(tt.field)
+			got, ok := lookupHeader(field, tt.tags...)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+
+	id, _ := typ.FieldByName("ID")
+	assert.True(t, hasTagOption(id, excelTag, keyOption))
+	assert.True(t, hasTagOption(id, excelTag, lockedOption))
+	amount, _ := typ.FieldByName("Amount")
+	assert.False(t, hasTagOption(amount, excelTag, " JPY"))
+}
+
+func TestSheet_HeaderWithComma(t *testing.T) {
+	type invoice struct {
+		Amount int `excel:"Amount, JPY"`
+	}
+
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[invoice](f, "Invoices", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	require.NoError(t, s.SetRow(&invoice{1200}))
+	require.NoError(t, s.Flush())
+
+	rows, err := f.GetRows("Invoices")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Amount, JPY"}, {"1200"}}, rows)
+}
@@ -10,13 +10,20 @@ type Sheet[M any] struct {
 // NewSheet creates a new exceltable.Sheet with the given sheet name and starting cell.
 //
 //	s, _ := exceltable.NewSheet[YourStruct](f, "NewSheet", "A1", true)
-func NewSheet[M any](f *File, name, cell string, active bool) (*Sheet[M], error) {
-	sb, err := newSheetBase[M](f, name, cell, active)
+func NewSheet[M any](f *File, name, cell string, active bool, opts ...SheetOption) (*Sheet[M], error) {
+	sb, err := newSheetBase[M](f, name, cell, active, opts...)
 	if err != nil {
 		return nil, err
 	}
+	return newSheet(sb)
+}
 
-	return &Sheet[M]{sb}, nil
+func newSheet[M any](sb *sheetBase[M]) (*Sheet[M], error) {
+	s := &Sheet[M]{sb}
+	if err := s.hideColumns(); err != nil {
+		return nil, err
+	}
+	return s, nil
 }
 
 // SetHeader writes the header row to the table.
@@ -31,37 +38,77 @@ func (s *Sheet[M]) SetHeader() error {
 
 // SetRow writes a row of data to the table.
 func (s *Sheet[M]) SetRow(obj *M) error {
+	if s.sealed {
+		return ErrTableOverlap
+	}
+
 	ptrV := reflect.ValueOf(obj)
 	v := ptrV.Elem()
 
-	col := 0
-	for i := range s.numField {
-		if s.skip[i] {
-			continue
-		}
-
+	for col, i := range s.fields {
 		field := v.Field(i)
-		if err := s.setCellValue(col, s.row, getUnderlyingValue(field)); err != nil {
-			return err
-		}
-
-		for _, rule := range s.rulesList[col] {
-			pred := rule.bind(ptrV)
-			b, err := callPredicate(pred, field)
-			if err != nil {
+		if s.formulas[col] != "" {
+			if err := s.setCellFormula(col, s.row); err != nil {
 				return err
 			}
-
-			if b {
-				if err := s.setCellStyle(col, s.row, rule.styleID); err != nil {
+		} else if s.isImage(col) {
+			if err := s.addImage(col, field, false); err != nil {
+				return err
+			}
+		} else if rt, ok := getUnderlyingValue(field).(RichText); ok {
+			if len(rt) > 0 {
+				cell := s.coordinatesToCellName(col, s.row)
+				if err := s.File.SetCellRichText(s.name, cell, rt); err != nil {
 					return err
 				}
-				break // NOTE: Break to prevent overwriting.
 			}
+			s.observeWidth(col, rt.String())
+		} else {
+			if err := s.setCellValue(col, s.row, getUnderlyingValue(field)); err != nil {
+				return err
+			}
+			s.observeWidth(col, formatValue(field))
 		}
 
-		col++
+		rule, err := s.matchRule(col, ptrV, field)
+		if err != nil {
+			return err
+		}
+		styleID := 0
+		if rule != nil {
+			styleID = rule.styleID
+		}
+		if styleID, err = s.linkStyle(col, field, styleID); err != nil {
+			return err
+		}
+		if styleID, err = s.cellStyle(col, styleID); err != nil {
+			return err
+		}
+		if styleID, err = s.repeatStyle(col, field, styleID); err != nil {
+			return err
+		}
+		if styleID != 0 {
+			if err := s.setCellStyle(col, s.row, styleID); err != nil {
+				return err
+			}
+		}
+		if rule != nil && s.opts.ruleComments {
+			if err := s.File.AddComment(s.name, s.ruleComment(col, s.row, rule)); err != nil {
+				return err
+			}
+		}
+		if err := s.setLink(col, field, false); err != nil {
+			return err
+		}
 	}
+	if height := s.imageRowHeight(v); height > 0 {
+		if err := s.File.SetRowHeight(s.name, s.y+s.row, height); err != nil {
+			return err
+		}
+	}
+	s.recordKey(v)
+	s.trackRepeats(v)
+	s.stats.addRow()
 	s.row++
 
 	return nil
@@ -71,11 +118,66 @@ func (s *Sheet[M]) setCellValue(col, row int, val any) error {
 	return s.File.File.SetCellValue(s.name, s.coordinatesToCellName(col, row), val)
 }
 
+func (s *Sheet[M]) setCellFormula(col, row int) error {
+	formula, err := s.formula(col, row)
+	if err != nil {
+		return err
+	}
+	return s.File.File.SetCellFormula(s.name, s.coordinatesToCellName(col, row), formula)
+}
+
 func (s *Sheet[M]) setCellStyle(col, row, styleID int) error {
 	cell := s.coordinatesToCellName(col, row)
 	return s.File.File.SetCellStyle(s.name, cell, cell, styleID)
 }
 
+// Flush applies the settings depending on the written data range, such as data validations,
+// the autofilter and column widths, and protects the sheet if WithSheetProtection is specified.
+// Hyperlinks to sheets not flushed yet are set when those sheets are flushed.
+//
+// It must be called after writing all data rows. Calls after the first successful one do nothing.
+// It returns ErrTableNotFound if formula columns have structured references but no table is added.
+func (s *Sheet[M]) Flush() error {
+	if s.flushed {
+		return nil
+	}
+	if err := s.checkTableRefs(); err != nil {
+		return err
+	}
+
+	if err := s.mergeRepeats(s); err != nil {
+		return err
+	}
+	if err := s.runDeferred(); err != nil {
+		return err
+	}
+	if err := s.setColumnWidths(); err != nil {
+		return err
+	}
+	if err := s.finalize(); err != nil {
+		return err
+	}
+	if err := s.resolveLinks(); err != nil { // NOTE: The sheet is marked as flushed after all the steps succeed.
+		return err
+	}
+	s.flushed = true
+	return nil
+}
+
+// setColumnWidths sets the column widths if WithAutoWidth is specified.
+func (s *Sheet[M]) setColumnWidths() error {
+	if s.opts.autoWidth == nil {
+		return nil
+	}
+	for col, width := range s.columnWidths() {
+		name := s.columnName(col)
+		if err := s.File.SetColWidth(s.name, name, name, width); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // AddDefaultTable creates a table with the default style to the sheet.
 //
 // It must be called after writing all data rows.
@@ -84,8 +186,33 @@ func (s *Sheet[M]) AddDefaultTable() error {
 }
 
 // AddTable creates a table with the specified style name to the sheet.
+// If any column has the total tag, the totals row is written below the data rows,
+// and SetRow afterwards returns ErrTableOverlap.
+// It returns ErrMergedCells if key cells are merged by WithMergedKeys, since tables cannot contain merged cells.
 //
 // It must be called after writing all data rows.
 func (s *Sheet[M]) AddTable(styleName string) error {
-	return s.File.File.AddTable(s.name, s.newTable(styleName))
+	if err := s.checkMerges(); err != nil {
+		return err
+	}
+	s.endRuns()
+	if s.totals != nil {
+		row := s.totalsRowIndex()
+		for col, cell := range s.totalsRow() {
+			if cell.Formula != "" {
+				if err := s.File.File.SetCellFormula(s.name, s.coordinatesToCellName(col, row), cell.Formula); err != nil {
+					return err
+				}
+			} else if err := s.setCellValue(col, row, cell.Value); err != nil {
+				return err
+			}
+		}
+		s.sealed = true // NOTE: Rows added afterwards would overwrite the totals row.
+	}
+
+	if err := s.File.File.AddTable(s.name, s.newTable(styleName)); err != nil {
+		return err
+	}
+	s.hasTable = true
+	return s.addTotalsRow()
 }
@@ -3,7 +3,6 @@ package exceltable
 import (
 	"fmt"
 	"reflect"
-	"strings"
 
 	"github.com/xuri/excelize/v2"
 )
@@ -12,6 +11,7 @@ import (
 const (
 	csvTag   string = "csv"
 	excelTag string = "excel"
+	jsonTag  string = "json"
 )
 
 // Default table style name.
@@ -22,14 +22,18 @@ type sheetRule struct {
 	pred     reflect.Value
 	funcT    reflect.Type
 	isMethod bool
+	tag      ruleTagType
+	key      predKeyType
 	styleID  int
 }
 
-func newSheetRule(pred reflect.Value, isMethod bool, styleID int) *sheetRule {
+func newSheetRule(pred reflect.Value, isMethod bool, tag ruleTagType, key predKeyType, styleID int) *sheetRule {
 	if !isMethod {
 		return &sheetRule{
 			pred:     pred,
 			isMethod: false,
+			tag:      tag,
+			key:      key,
 			styleID:  styleID,
 		}
 	}
@@ -47,6 +51,8 @@ func newSheetRule(pred reflect.Value, isMethod bool, styleID int) *sheetRule {
 		pred:     pred,
 		funcT:    reflect.FuncOf(in[1:], out, false),
 		isMethod: true,
+		tag:      tag,
+		key:      key,
 		styleID:  styleID,
 	}
 }
@@ -61,113 +67,215 @@ func (sr *sheetRule) bind(ptrV reflect.Value) reflect.Value {
 	})
 }
 
+// commentAuthor is the author of comments added by exceltable.
+const commentAuthor = "exceltable"
+
 type sheetBase[M any] struct {
-	File       *File
-	name       string         // sheet name
-	x, y       int            // starting cell coordinates
-	row        int            // current number of rows
-	tableWidth int            // table width (number of columns)
-	numField   int            // number of struct fields
-	skip       []bool         // whether to skip each struct field
-	header     []any          // header values
-	rulesList  [][]*sheetRule // rules for each column
+	*schema
+	File        *File
+	name        string                     // sheet name
+	x, y        int                        // starting cell coordinates
+	row         int                        // current number of rows
+	table       string                     // table name
+	opts        *sheetOptions              // sheet options
+	stats       *sheetStats                // rule hits for the summary sheet
+	validations []*excelize.DataValidation // data validation of each column, or nil
+	editable    []bool                     // whether each column is editable on a protected sheet
+	totals      []string                   // total of each column in the totals row, or nil without totals row
+	widths      []int                      // maximum width of values in each column, for WithAutoWidth
+	repeats     []*repeatRun               // run of equal values of each column with the "mergeRepeats" option, or nil
+	merges      []mergeRange               // cells of the runs ended, merged when the sheet is flushed
+	mergedKeys  bool                       // whether the key cells of groups are merged by WithMergedKeys
+	links       []string                   // link tag of each column, or nil without links
+	keyCol      int                        // key column which internal links refer to, or -1
+	linkTargets []string                   // sheets linked, which must be flushed first, for SheetWithStreamWriter
+	images      []float64                  // row height of each column with the image tag, or nil without images
+	hasTable    bool                       // whether a table is added
+	sealed      bool                       // whether rows can no longer be added, since another table or the totals row is placed below
+	flushed     bool                       // whether the sheet is flushed
+	deferred    []func() error             // operations deferred until the sheet is flushed
 }
 
-func newSheetBase[M any](f *File, name, cell string, active bool) (*sheetBase[M], error) {
-	t := reflect.TypeFor[M]()
-	if t.Kind() != reflect.Struct {
-		return nil, ErrNotStructType
+func newSheetBase[M any](f *File, name, cell string, active bool, opts ...SheetOption) (*sheetBase[M], error) {
+	x, y, err := excelize.CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
 	}
-	ptrT := reflect.PointerTo(t)
+	return newSheetBaseAt[M](f, name, x, y, active, false, opts...)
+}
 
-	idx, err := f.NewSheet(name)
+// newSheetBaseAt creates a sheetBase starting at (x, y).
+// If shared is true, the table is placed on the existing sheet together with other tables.
+//
+// The struct tags are validated before the sheet is created, so that nothing is left in f on error.
+func newSheetBaseAt[M any](f *File, name string, x, y int, active, shared bool, opts ...SheetOption) (*sheetBase[M], error) {
+	sc, err := newSchema(reflect.TypeFor[M](), f.rules, excelTag, csvTag)
 	if err != nil {
 		return nil, err
 	}
-	if active {
-		f.SetActiveSheet(idx)
+	sc.addFormulaColumns()
+	o := newSheetOptions(opts)
+
+	validations := make([]*excelize.DataValidation, sc.tableWidth)
+	for col, i := range sc.fields {
+		if validations[col], err = newDataValidation(sc.typ.Field(i), sc.header[col].(string)); err != nil {
+			return nil, err
+		}
 	}
 
-	x, y, err := excelize.CellNameToCoordinates(cell)
+	images, err := imageHeights(sc)
 	if err != nil {
 		return nil, err
 	}
 
-	tableWidth, numField := 0, t.NumField()
-	skip := make([]bool, numField)
-	header := make([]any, 0, numField)
-	rulesList := make([][]*sheetRule, 0, numField)
-	for i := range numField {
-		field := t.Field(i)
-		if field.PkgPath != "" { // field is unexported.
-			skip[i] = true
-			continue
-		}
-
-		h := field.Tag.Get(excelTag)
-		if h == "" {
-			h = field.Tag.Get(csvTag)
+	sb := &sheetBase[M]{
+		schema:      sc,
+		File:        f,
+		name:        name,
+		x:           x,
+		y:           y,
+		row:         1,
+		opts:        o,
+		validations: validations,
+		editable:    editableColumns(sc),
+		totals:      totals(sc),
+		widths:      make([]int, sc.tableWidth),
+		repeats:     repeatRuns(sc),
+		links:       columnLinks(sc),
+		keyCol:      keyColumn(sc),
+		images:      images,
+	}
+	for col := range sc.formulas {
+		if _, err := sb.formula(col, 1); err != nil { // NOTE: Check the references in advance.
+			return nil, err
 		}
+	}
 
-		switch h {
-		case "":
-			h = field.Name
-		case "-":
-			skip[i] = true
-			continue
+	var idx int
+	if shared {
+		if idx, err = f.GetSheetIndex(name); err != nil {
+			return nil, err
 		}
-
-		header = append(header, h)
-		tableWidth++
-
-		rules := make([]*sheetRule, 0)
-		for _, rule := range f.rules {
-			for key := range strings.SplitSeq(field.Tag.Get(rule.tag), ",") {
-				switch key {
-				case "", "-":
-					// ignore
-				default:
-					if method, ok := ptrT.MethodByName(key); ok {
-						rules = append(rules, newSheetRule(method.Func, true, rule.styleID))
-						break
-					}
-
-					if function, ok := predicates.Load(key); ok {
-						rules = append(rules, newSheetRule(reflect.ValueOf(function), false, rule.styleID))
-						break
-					}
-
-					return nil, ErrUnknownPredicate
-				}
-			}
+		sb.table, err = f.reserveTableName(name, o.tableName, nil)
+	} else {
+		idx, sb.table, err = f.prepareSheet(name, o.overwrite, o.tableName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := sb.freezeHeader(); err != nil {
+		f.releaseTableName(sb.table)
+		if !shared {
+			f.releaseSheet(name)
+			_ = f.DeleteSheet(name) // NOTE: The error of freezeHeader is more relevant.
 		}
-		rulesList = append(rulesList, rules)
+		return nil, err
+	}
+	if active {
+		f.SetActiveSheet(idx)
 	}
 
-	return &sheetBase[M]{
-		File:       f,
-		name:       name,
-		x:          x,
-		y:          y,
-		row:        1,
-		tableWidth: tableWidth,
-		numField:   numField,
-		skip:       skip,
-		header:     header,
-		rulesList:  rulesList,
-	}, nil
+	f.usage.use(sc.rulesList...)
+	sb.stats = newSheetStats(name, sc)
+	f.addStats(sb.stats)
+	return sb, nil
 }
 
 func (s *sheetBase[M]) newTable(styleName string) *excelize.Table {
 	topLeftCell := s.coordinatesToCellName(0, 0)
-	bottomRightCell := s.coordinatesToCellName(max(s.tableWidth-1, 1), max(s.row-1, 1))
+	bottomRow := max(s.row-1, 1)
+	if s.totals != nil {
+		bottomRow = s.totalsRowIndex()
+	}
+	bottomRightCell := s.coordinatesToCellName(max(s.tableWidth-1, 1), bottomRow)
 	return &excelize.Table{
 		Range:     fmt.Sprintf("%s:%s", topLeftCell, bottomRightCell),
-		Name:      fmt.Sprintf("%sTable", s.name),
+		Name:      s.tableName(),
 		StyleName: styleName,
 	}
 }
 
+// bounds returns the range occupied by the table, including the totals row.
+func (s *sheetBase[M]) bounds() (x1, y1, x2, y2 int) {
+	bottomRow := max(s.row-1, 1) // NOTE: Tables have at least two rows.
+	if s.totals != nil {
+		bottomRow = s.totalsRowIndex()
+	}
+	return s.x, s.y, s.x + max(s.tableWidth-1, 0), s.y + bottomRow
+}
+
+// totalsRowIndex returns the row of the totals row, just below the data rows.
+func (s *sheetBase[M]) totalsRowIndex() int {
+	return max(s.row, 2) // NOTE: Tables have at least one data row.
+}
+
+// seal prevents rows from being added, since another table is placed below.
+func (s *sheetBase[M]) seal() error {
+	s.sealed = true
+	return nil
+}
+
+// tableName returns the name of the table created by AddTable.
+func (s *sheetBase[M]) tableName() string {
+	return s.table
+}
+
+// matchRule returns the first rule of column col satisfied by field, or nil if there is none,
+// and counts the hit for the legend and summary sheets.
+func (s *sheetBase[M]) matchRule(col int, ptrV, field reflect.Value) (*sheetRule, error) {
+	rule, err := s.schema.matchRule(col, ptrV, field)
+	if rule != nil {
+		s.File.usage.hit(rule)
+		s.stats.hit(col, rule.tag, formatValue(field))
+	}
+	return rule, err
+}
+
+// ruleComment returns the comment on the cell at (col, row) explaining rule.
+func (s *sheetBase[M]) ruleComment(col, row int, rule *sheetRule) excelize.Comment {
+	return excelize.Comment{
+		Author: commentAuthor,
+		Cell:   s.coordinatesToCellName(col, row),
+		Text:   fmt.Sprintf("%s: %s", rule.tag, describePredicate(rule.key)),
+	}
+}
+
+// finalize applies the settings depending on the written data range, such as data validations
+// and the autofilter, and protects the sheet.
+func (s *sheetBase[M]) finalize() error {
+	if err := s.addAutoFilter(); err != nil {
+		return err
+	}
+	for col, dv := range s.validations {
+		if dv == nil {
+			continue
+		}
+
+		v := *dv
+		v.SetSqref(s.columnRangeRef(col))
+		if err := s.File.AddDataValidation(s.name, &v); err != nil {
+			return err
+		}
+	}
+	return s.protect()
+}
+
+// columnRangeRef returns the range reference of the data cells of column col, such as "B2:B10".
+func (s *sheetBase[M]) columnRangeRef(col int) string {
+	return fmt.Sprintf("%s:%s", s.coordinatesToCellName(col, 1), s.coordinatesToCellName(col, max(s.row-1, 1)))
+}
+
+// runDeferred runs the deferred operations in order.
+func (s *sheetBase[M]) runDeferred() error {
+	for _, fn := range s.deferred {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	s.deferred = nil
+	return nil
+}
+
 func (s *sheetBase[M]) coordinatesToCellName(col, row int, abs ...bool) string {
 	cell, err := excelize.CoordinatesToCellName(s.x+col, s.y+row, abs...)
 	if err != nil {
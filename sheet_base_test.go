@@ -17,7 +17,7 @@ func Test_sheetRule_bind(t *testing.T) {
 		method, ok := ptrT.MethodByName("IsChild")
 		require.True(t, ok)
 
-		sr := newSheetRule(method.Func, true, 0)
+		sr := newSheetRule(method.Func, true, warnTag, "IsChild", 0)
 		b, err := callPredicate(sr.bind(ptrV), reflect.Value{})
 		require.NoError(t, err)
 		assert.True(t, b)
@@ -27,7 +27,7 @@ func Test_sheetRule_bind(t *testing.T) {
 		function, ok := predicates.Load("isNewFace")
 		require.True(t, ok)
 
-		sr := newSheetRule(reflect.ValueOf(function), false, 0)
+		sr := newSheetRule(reflect.ValueOf(function), false, "newface", "isNewFace", 0)
 		b, err := callPredicate(sr.bind(ptrV), ptrV.Elem().FieldByName("Name"))
 		require.NoError(t, err)
 		assert.True(t, b)
@@ -3,8 +3,33 @@ package exceltable
 import (
 	"path/filepath"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
 )
 
+func TestSheet_WithRuleComments(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[person](f, "test", "A1", true, WithRuleComments())
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetHeader())
+	for _, p := range persons {
+		require.NoError(t, s.SetRow(p))
+	}
+
+	comments, err := f.GetComments("test")
+	require.NoError(t, err)
+	require.Len(t, comments, 6)
+	assert.Equal(t, "B2", comments[0].Cell)
+	assert.Equal(t, "newface: isNewFace", comments[0].Text)
+	assert.Equal(t, "C2", comments[1].Cell)
+	assert.Equal(t, "warn: younger than 18", comments[1].Text)
+	assert.Equal(t, excelize.Comment{Author: commentAuthor, Cell: "E3", Text: "error: nil"}, comments[2])
+}
+
 func BenchmarkWrite(b *testing.B) {
 	for b.Loop() {
 		f, err := NewFile()
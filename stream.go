@@ -1,6 +1,7 @@
 package exceltable
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/xuri/excelize/v2"
@@ -10,13 +11,23 @@ import (
 type SheetWithStreamWriter[M any] struct {
 	*sheetBase[M]
 	*excelize.StreamWriter
+	pending   []pendingRow // rows held back until column widths are set, for WithAutoWidth
+	widthsSet bool         // whether column widths are set
+	layout    *SheetLayout // layout sharing StreamWriter with other tables, or nil
+}
+
+// pendingRow is a row held back by SheetWithStreamWriter.
+type pendingRow struct {
+	cell   string
+	values []any
+	opts   []excelize.RowOpts
 }
 
 // NewSheetWithStreamWriter creates a new exceltable.SheetWithStreamWriter with the given sheet name and starting cell.
 //
 //	ssw, _ := exceltable.NewSheetWithStreamWriter[YourStruct](f, "NewSheet", "A1", true)
-func NewSheetWithStreamWriter[M any](f *File, name, cell string, active bool) (*SheetWithStreamWriter[M], error) {
-	sb, err := newSheetBase[M](f, name, cell, active)
+func NewSheetWithStreamWriter[M any](f *File, name, cell string, active bool, opts ...SheetOption) (*SheetWithStreamWriter[M], error) {
+	sb, err := newSheetBase[M](f, name, cell, active, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -25,61 +36,168 @@ func NewSheetWithStreamWriter[M any](f *File, name, cell string, active bool) (*
 	if err != nil {
 		return nil, err
 	}
+	return newSheetWithStreamWriter(sb, streamWriter, nil)
+}
 
-	return &SheetWithStreamWriter[M]{sb, streamWriter}, nil
+func newSheetWithStreamWriter[M any](sb *sheetBase[M], streamWriter *excelize.StreamWriter, layout *SheetLayout) (*SheetWithStreamWriter[M], error) {
+	ssw := &SheetWithStreamWriter[M]{sheetBase: sb, StreamWriter: streamWriter, layout: layout}
+	if err := ssw.hideColumns(); err != nil {
+		return nil, err
+	}
+	return ssw, nil
 }
 
 // SetHeader writes the header row to the table.
 //
 // It must be called before writing any data rows.
 func (ssw *SheetWithStreamWriter[M]) SetHeader() error {
-	return ssw.StreamWriter.SetRow(ssw.coordinatesToCellName(0, 0), ssw.header)
+	return ssw.setRow(ssw.coordinatesToCellName(0, 0), ssw.header)
 }
 
 // SetRow writes a row of data to the table.
 func (ssw *SheetWithStreamWriter[M]) SetRow(obj *M) error {
+	return ssw.setDataRow(obj)
+}
+
+// setDataRow writes a row of data to the table with opts.
+func (ssw *SheetWithStreamWriter[M]) setDataRow(obj *M, opts ...excelize.RowOpts) error {
+	if ssw.sealed {
+		return ErrTableOverlap
+	}
+
 	ptrV := reflect.ValueOf(obj)
 	v := ptrV.Elem()
 
 	values := make([]any, 0, ssw.tableWidth)
-	col := 0
-	for i := range ssw.numField {
-		if ssw.skip[i] {
-			continue
-		}
-
+	for col, i := range ssw.fields {
 		field := v.Field(i)
 		styleID := 0
 
-		for _, rule := range ssw.rulesList[col] {
-			pred := rule.bind(ptrV)
-			b, err := callPredicate(pred, field)
+		rule, err := ssw.matchRule(col, ptrV, field)
+		if err != nil {
+			return err
+		}
+		if rule != nil {
+			styleID = rule.styleID
+			if ssw.opts.ruleComments {
+				comment := ssw.ruleComment(col, ssw.row, rule)
+				ssw.deferred = append(ssw.deferred, func() error {
+					return ssw.File.AddComment(ssw.name, comment)
+				})
+			}
+		}
+		if styleID, err = ssw.linkStyle(col, field, styleID); err != nil {
+			return err
+		}
+		if styleID, err = ssw.cellStyle(col, styleID); err != nil {
+			return err
+		}
+		if styleID, err = ssw.repeatStyle(col, field, styleID); err != nil {
+			return err
+		}
+		if err := ssw.setLink(col, field, true); err != nil {
+			return err
+		}
+
+		if ssw.formulas[col] != "" {
+			formula, err := ssw.formula(col, ssw.row)
 			if err != nil {
 				return err
 			}
+			values = append(values, &excelize.Cell{
+				StyleID: styleID,
+				Formula: formula,
+			})
+			continue
+		}
 
-			if b {
-				styleID = rule.styleID
-				break // NOTE: Break to prevent overwriting.
+		if ssw.isImage(col) {
+			if err := ssw.addImage(col, field, true); err != nil {
+				return err
 			}
+			values = append(values, &excelize.Cell{StyleID: styleID})
+			continue
 		}
 
+		value := getUnderlyingValue(field)
+		if rt, ok := value.(RichText); ok {
+			value = nil
+			if len(rt) > 0 {
+				value = []excelize.RichTextRun(rt) // NOTE: excelize.StreamWriter does not accept named slice types.
+			}
+		}
 		values = append(values, &excelize.Cell{
 			StyleID: styleID,
-			Value:   getUnderlyingValue(field),
+			Value:   value,
 		})
-		col++
+		ssw.observeWidth(col, formatValue(field))
 	}
 
+	if height := ssw.imageRowHeight(v); height > 0 {
+		if len(opts) == 0 {
+			opts = []excelize.RowOpts{{}}
+		}
+		opts[0].Height = height
+	}
 	cell := ssw.coordinatesToCellName(0, ssw.row)
-	if err := ssw.StreamWriter.SetRow(cell, values); err != nil {
+	if err := ssw.setRow(cell, values, opts...); err != nil {
 		return err
 	}
+	ssw.recordKey(v)
+	ssw.trackRepeats(v)
 
+	ssw.stats.addRow()
 	ssw.row++
 	return nil
 }
 
+// Flush applies the operations deferred until the sheet data is written, such as comments,
+// and the settings depending on the written data range, such as data validations, the autofilter
+// and sheet protection, and then ends the streaming writing process.
+//
+// The deferred operations are applied after all rows are written but before excelize.StreamWriter.Flush,
+// since excelize writes the parts of the worksheet other than rows, such as comments, pictures and hyperlinks,
+// when the rows are flushed, and discards the changes made to the worksheet afterwards.
+// They write no cells, which must be written through excelize.StreamWriter only.
+//
+// It returns ErrTableNotFound if formula columns have structured references but no table is added,
+// and ErrLinkTarget if hyperlinks refer to sheets not flushed yet, since they cannot be added afterwards.
+// In that case, nothing is written, and Flush can be called again after flushing the sheets linked.
+func (ssw *SheetWithStreamWriter[M]) Flush() error {
+	if ssw.flushed {
+		return nil
+	}
+	if err := ssw.checkTableRefs(); err != nil {
+		return err
+	}
+	if err := ssw.checkLinkTargets(); err != nil {
+		return err
+	}
+
+	if err := ssw.mergeRepeats(ssw); err != nil {
+		return err
+	}
+	if err := ssw.setColumnWidths(); err != nil {
+		return err
+	}
+	if err := ssw.runDeferred(); err != nil { // NOTE: Applied before the rows are flushed. See the doc comment.
+		return err
+	}
+	if err := ssw.finalize(); err != nil {
+		return err
+	}
+	if ssw.layout == nil { // NOTE: The shared StreamWriter is flushed by SheetLayout.Flush.
+		if err := ssw.StreamWriter.Flush(); err != nil {
+			return err
+		}
+	}
+	if err := ssw.resolveLinks(); err != nil { // NOTE: The sheet is marked as flushed after all the steps succeed.
+		return err
+	}
+	ssw.flushed = true
+	return nil
+}
+
 // AddDefaultTable creates a table with the default style to the sheet.
 //
 // It must be called after writing all data rows.
@@ -88,8 +206,82 @@ func (ssw *SheetWithStreamWriter[M]) AddDefaultTable() error {
 }
 
 // AddTable creates a table with the specified style name to the sheet.
+// If any column has the total tag, the totals row is written below the data rows,
+// and SetRow afterwards returns ErrTableOverlap.
+// It returns ErrMergedCells if key cells are merged by WithMergedKeys, since tables cannot contain merged cells.
 //
 // It must be called after writing all data rows.
 func (ssw *SheetWithStreamWriter[M]) AddTable(styleName string) error {
-	return ssw.StreamWriter.AddTable(ssw.newTable(styleName))
+	if err := ssw.checkMerges(); err != nil {
+		return err
+	}
+	if ssw.layout != nil {
+		if ssw.layout.hasStreamTable {
+			return fmt.Errorf("%w: only one table can be added per sheet", ErrStreamLayout)
+		}
+		ssw.layout.hasStreamTable = true
+	}
+	ssw.endRuns()
+	if err := ssw.setColumnWidths(); err != nil {
+		return err
+	}
+
+	if ssw.totals != nil {
+		cells := ssw.totalsRow()
+		values := make([]any, 0, len(cells))
+		for _, cell := range cells {
+			values = append(values, cell)
+		}
+		if err := ssw.setRow(ssw.coordinatesToCellName(0, ssw.totalsRowIndex()), values); err != nil {
+			return err
+		}
+		ssw.sealed = true // NOTE: Rows added afterwards would overwrite the totals row.
+	}
+
+	if err := ssw.StreamWriter.AddTable(ssw.newTable(styleName)); err != nil {
+		return err
+	}
+	ssw.hasTable = true
+	return ssw.addTotalsRow()
+}
+
+// seal prevents rows from being added, and writes the rows held back, since another table is placed below.
+func (ssw *SheetWithStreamWriter[M]) seal() error {
+	ssw.sealed = true
+	return ssw.setColumnWidths()
+}
+
+// setRow writes a row, or holds it back until column widths are set if WithAutoWidth is specified.
+func (ssw *SheetWithStreamWriter[M]) setRow(cell string, values []any, opts ...excelize.RowOpts) error {
+	if ssw.opts.autoWidth == nil || ssw.widthsSet {
+		return ssw.StreamWriter.SetRow(cell, values, opts...)
+	}
+
+	ssw.pending = append(ssw.pending, pendingRow{cell, values, opts})
+	if len(ssw.pending) <= 1+autoWidthSampleRows { // NOTE: The header row is held back as well.
+		return nil
+	}
+	return ssw.setColumnWidths()
+}
+
+// setColumnWidths sets the column widths computed from the rows held back, and then writes them.
+func (ssw *SheetWithStreamWriter[M]) setColumnWidths() error {
+	if ssw.opts.autoWidth == nil || ssw.widthsSet {
+		return nil
+	}
+
+	for col, width := range ssw.columnWidths() {
+		if err := ssw.StreamWriter.SetColWidth(ssw.x+col, ssw.x+col, width); err != nil {
+			return err
+		}
+	}
+	ssw.widthsSet = true
+
+	for _, row := range ssw.pending {
+		if err := ssw.StreamWriter.SetRow(row.cell, row.values, row.opts...); err != nil {
+			return err
+		}
+	}
+	ssw.pending = nil
+	return nil
 }
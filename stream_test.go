@@ -1,10 +1,48 @@
 package exceltable
 
 import (
+	"archive/zip"
+	"io"
 	"path/filepath"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func TestSheetWithStreamWriter_WithRuleComments(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	ssw, err := NewSheetWithStreamWriter[person](f, "test", "A1", true, WithRuleComments())
+	require.NoError(t, err)
+
+	require.NoError(t, ssw.SetHeader())
+	for _, p := range persons {
+		require.NoError(t, ssw.SetRow(p))
+	}
+	require.NoError(t, ssw.AddDefaultTable())
+	require.NoError(t, ssw.Flush())
+
+	comments, err := f.GetComments("test")
+	require.NoError(t, err)
+	require.Len(t, comments, 6)
+	assert.Equal(t, "C4", comments[4].Cell)
+	assert.Equal(t, "warn: IsOld", comments[4].Text)
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+
+	// NOTE: The comments are lost unless the worksheet written by excelize.StreamWriter.Flush refers to them.
+	zr, err := zip.OpenReader(path)
+	require.NoError(t, err)
+	defer zr.Close()
+	r, err := zr.Open("xl/worksheets/sheet2.xml")
+	require.NoError(t, err)
+	sheetXML, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Contains(t, string(sheetXML), "<legacyDrawing ")
+}
+
 func BenchmarkWriteWithStreamWriter(b *testing.B) {
 	for b.Loop() {
 		f, err := NewFile()
@@ -0,0 +1,243 @@
+package exceltable
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// topValuesLimit is the number of top offending values listed per column and rule in the summary sheet.
+const topValuesLimit = 5
+
+// sheetStats holds the counters of rule hits accumulated while writing a sheet.
+type sheetStats struct {
+	sync.Mutex
+	name     string
+	header   []any
+	hasRules []bool                           // whether each column has any rules
+	rows     int                              // number of data rows
+	rowsWith map[ruleTagType]int              // number of rows with at least one hit per rule tag
+	cells    []map[ruleTagType]int            // number of hits per column and rule tag
+	values   []map[ruleTagType]map[string]int // number of hits per column, rule tag and value
+	pending  map[ruleTagType]bool             // rule tags hit in the current row
+}
+
+func newSheetStats(name string, sc *schema) *sheetStats {
+	st := &sheetStats{
+		name:     name,
+		header:   sc.header,
+		hasRules: make([]bool, sc.tableWidth),
+		rowsWith: make(map[ruleTagType]int),
+		cells:    make([]map[ruleTagType]int, sc.tableWidth),
+		values:   make([]map[ruleTagType]map[string]int, sc.tableWidth),
+		pending:  make(map[ruleTagType]bool),
+	}
+	for col := range sc.tableWidth {
+		st.hasRules[col] = len(sc.rulesList[col]) > 0
+		st.cells[col] = make(map[ruleTagType]int)
+		st.values[col] = make(map[ruleTagType]map[string]int)
+	}
+	return st
+}
+
+// hit counts a cell of column col with value satisfying the rule of tag.
+func (st *sheetStats) hit(col int, tag ruleTagType, value string) {
+	st.Lock()
+	defer st.Unlock()
+
+	st.cells[col][tag]++
+	if st.values[col][tag] == nil {
+		st.values[col][tag] = make(map[string]int)
+	}
+	st.values[col][tag][value]++
+	st.pending[tag] = true
+}
+
+// addRow counts a data row, together with the rule tags hit in it.
+func (st *sheetStats) addRow() {
+	st.Lock()
+	defer st.Unlock()
+
+	st.rows++
+	for tag := range st.pending {
+		st.rowsWith[tag]++
+	}
+	clear(st.pending)
+}
+
+// SummaryOption configures AddSummarySheet.
+type SummaryOption func(*summaryOptions)
+
+type summaryOptions struct {
+	chart bool
+}
+
+// WithSummaryChart adds a bar chart of the number of highlighted cells per column to the summary sheet.
+func WithSummaryChart() SummaryOption {
+	return func(o *summaryOptions) {
+		o.chart = true
+	}
+}
+
+// AddSummarySheet adds a new sheet which summarizes the rule hits accumulated while writing
+// the sheets of this workbook: the number of rows and the percentage of rows with at least one
+// hit per sheet and rule, the number of highlighted cells per column, and the top offending values.
+//
+// It must be called after writing (and flushing) all sheets.
+// It returns ErrSheetExists if the sheet exists.
+func (f *File) AddSummarySheet(name string, opts ...SummaryOption) error {
+	o := &summaryOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if _, _, err := f.prepareSheet(name, false); err != nil {
+		return err
+	}
+
+	headerStyleID, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	percentStyleID, err := f.NewStyle(&excelize.Style{NumFmt: 10}) // 0.00%
+	if err != nil {
+		return err
+	}
+
+	tags := make([]ruleTagType, 0, len(f.rules))
+	for _, rule := range f.rules {
+		if !slices.Contains(tags, rule.tag) {
+			tags = append(tags, rule.tag)
+		}
+	}
+
+	row := 1
+	setRow := func(values []any, styleID int) error {
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		if err := f.SetSheetRow(name, cell, &values); err != nil {
+			return err
+		}
+		if styleID != 0 {
+			end, _ := excelize.CoordinatesToCellName(len(values), row)
+			if err := f.SetCellStyle(name, cell, end, styleID); err != nil {
+				return err
+			}
+		}
+		row++
+		return nil
+	}
+
+	f.mu.Lock()
+	stats := slices.Clone(f.stats)
+	f.mu.Unlock()
+
+	// Rows per sheet.
+	header := []any{"Sheet", "Rows"}
+	for _, tag := range tags {
+		header = append(header, fmt.Sprintf("Rows with %s", tag), fmt.Sprintf("%s rate", tag))
+	}
+	if err := setRow(header, headerStyleID); err != nil {
+		return err
+	}
+	for _, st := range stats {
+		st.Lock()
+		values := []any{st.name, st.rows}
+		for _, tag := range tags {
+			values = append(values, st.rowsWith[tag], float64(st.rowsWith[tag])/float64(max(st.rows, 1)))
+		}
+		st.Unlock()
+
+		if err := setRow(values, 0); err != nil {
+			return err
+		}
+		for i := range tags {
+			cell, _ := excelize.CoordinatesToCellName(4+2*i, row-1)
+			if err := f.SetCellStyle(name, cell, cell, percentStyleID); err != nil {
+				return err
+			}
+		}
+	}
+	row++
+
+	// Highlighted cells per column.
+	header = []any{"Sheet", "Column"}
+	for _, tag := range tags {
+		header = append(header, tag)
+	}
+	if err := setRow(header, headerStyleID); err != nil {
+		return err
+	}
+	cellsTop := row
+	for _, st := range stats {
+		st.Lock()
+		for col, h := range st.header {
+			if !st.hasRules[col] {
+				continue
+			}
+			values := []any{st.name, h}
+			for _, tag := range tags {
+				values = append(values, st.cells[col][tag])
+			}
+			if err := setRow(values, 0); err != nil {
+				st.Unlock()
+				return err
+			}
+		}
+		st.Unlock()
+	}
+	cellsBottom := row - 1
+	row++
+
+	// Top offending values.
+	if err := setRow([]any{"Sheet", "Column", "Rule", "Value", "Count"}, headerStyleID); err != nil {
+		return err
+	}
+	for _, st := range stats {
+		st.Lock()
+		for col, h := range st.header {
+			for _, tag := range tags {
+				counts := st.values[col][tag]
+				values := slices.SortedFunc(maps.Keys(counts), func(a, b string) int {
+					return cmp.Or(cmp.Compare(counts[b], counts[a]), cmp.Compare(a, b))
+				})
+				for _, v := range values[:min(len(values), topValuesLimit)] {
+					if err := setRow([]any{st.name, h, tag, v, counts[v]}, 0); err != nil {
+						st.Unlock()
+						return err
+					}
+				}
+			}
+		}
+		st.Unlock()
+	}
+
+	if !o.chart || cellsBottom < cellsTop {
+		return nil
+	}
+
+	chart := &excelize.Chart{
+		Type:   excelize.Bar,
+		Series: make([]excelize.ChartSeries, 0, len(tags)),
+		Title:  []excelize.RichTextRun{{Text: "Highlighted cells per column"}},
+	}
+	for i := range tags {
+		col, _ := excelize.ColumnNumberToName(3 + i)
+		chart.Series = append(chart.Series, excelize.ChartSeries{
+			Name:       fmt.Sprintf("%s!$%s$%d", quoteSheetName(name), col, cellsTop-1),
+			Categories: fmt.Sprintf("%s!$A$%d:$B$%d", quoteSheetName(name), cellsTop, cellsBottom),
+			Values:     fmt.Sprintf("%s!$%s$%d:$%s$%d", quoteSheetName(name), col, cellsTop, col, cellsBottom),
+		})
+	}
+	anchor, _ := excelize.CoordinatesToCellName(3+2*len(tags)+1, 1)
+	return f.AddChart(name, anchor, chart)
+}
+
+// quoteSheetName quotes sheet name for use in references, such as 'Sheet 1'!A1.
+func quoteSheetName(name string) string {
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
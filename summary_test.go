@@ -0,0 +1,61 @@
+package exceltable
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_AddSummarySheet(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	ssw, err := NewSheetWithStreamWriter[person](f, "test", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, ssw.SetHeader())
+	for _, p := range persons {
+		require.NoError(t, ssw.SetRow(p))
+	}
+	require.NoError(t, ssw.Flush())
+
+	require.NoError(t, f.AddSummarySheet("Summary", WithSummaryChart()))
+
+	rows, err := f.GetRows("Summary")
+	require.NoError(t, err)
+	n := len(f.rules)
+	assert.Equal(t, []string{"Sheet", "Rows", "Rows with error", "error rate"}, rows[0][:4])
+	assert.Equal(t, []string{"test", "3", "2", "66.67%", "2", "66.67%"}, rows[1][:6]) // error, warn
+
+	assert.Equal(t, []string{"Sheet", "Column", "error", "warn"}, rows[3][:4])
+	assert.Equal(t, []string{"test", "ID", "1", "0"}, rows[4][:4])
+	assert.Equal(t, []string{"test", "年齢", "0", "2"}, rows[6][:4])
+	assert.Len(t, rows[4], 2+n)
+
+	assert.Equal(t, []string{"Sheet", "Column", "Rule", "Value", "Count"}, rows[9])
+	assert.Equal(t, []string{"test", "ID", "error", "", "1"}, rows[10])
+	assert.Equal(t, []string{"test", "氏名", "newface", "Alice", "1"}, rows[11])
+	assert.Equal(t, []string{"test", "年齢", "warn", "100", "1"}, rows[12])
+	assert.Equal(t, []string{"test", "年齢", "warn", "17", "1"}, rows[13])
+
+	_, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+}
+
+func TestFile_AddSummarySheet_SheetExists(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[person](f, "Persons", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	require.NoError(t, s.SetRow(persons[0]))
+	require.NoError(t, s.Flush())
+
+	assert.ErrorIs(t, f.AddSummarySheet("Persons"), ErrSheetExists)
+	rows, err := f.GetRows("Persons")
+	require.NoError(t, err)
+	assert.Len(t, rows, 2)
+}
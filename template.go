@@ -0,0 +1,212 @@
+package exceltable
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// FillTemplate writes rows into the existing table named tableName,
+// typically a pre-styled empty table in a template workbook.
+//
+// Table columns are mapped to fields of M by header text (header value or field name);
+// unmapped columns are left untouched. The table is resized to fit rows by inserting or
+// removing worksheet rows inside it, so formulas, defined names and chart series that refer
+// to the table range follow the new size. New rows inherit the cell styles of the first data
+// row of the template, as well as the formulas of unmapped columns, which should therefore
+// use structured references such as "=[@Qty]*[@Price]". Cells matching rules keep the template
+// style, such as borders and number formats, with the fill and font of the rule style overlaid.
+func FillTemplate[M any](f *File, tableName string, rows []*M) error {
+	sheet, table, err := findTable(f, tableName)
+	if err != nil {
+		return err
+	}
+
+	sc, err := newSchema(reflect.TypeFor[M](), f.rules, excelTag, csvTag)
+	if err != nil {
+		return err
+	}
+
+	x1, y1, x2, y2, err := rangeRefToCoordinates(table.Range)
+	if err != nil {
+		return err
+	}
+
+	width := x2 - x1 + 1
+	cols := make([]int, width) // schema column of each table column, or -1.
+	styles := make([]int, width)
+	formulas := make([]string, width)
+	for i := range width {
+		header, err := getCellValue(f, sheet, x1+i, y1)
+		if err != nil {
+			return err
+		}
+		cols[i] = -1
+		if col, ok := sc.columnByHeader(header); ok {
+			cols[i] = col
+			f.usage.use(sc.rulesList[col])
+		}
+
+		cell, _ := excelize.CoordinatesToCellName(x1+i, y1+1)
+		if styles[i], err = f.GetCellStyle(sheet, cell); err != nil {
+			return err
+		}
+		if formulas[i], err = f.GetCellFormula(sheet, cell); err != nil {
+			return err
+		}
+	}
+
+	// Resize the table. NOTE: A table must have at least one data row.
+	// Rows are inserted before the last data row so that A1 references spanning
+	// two or more data rows are expanded rather than shifted.
+	capacity, n := y2-y1, max(len(rows), 1)
+	switch {
+	case n > capacity:
+		if err := f.InsertRows(sheet, y2, n-capacity); err != nil {
+			return err
+		}
+		for y := y1 + 1; y <= y1+n; y++ {
+			for i := range width {
+				if err := setTemplateCell(f, sheet, x1+i, y, styles[i], formulas[i], cols[i] < 0); err != nil {
+					return err
+				}
+			}
+		}
+	case n < capacity:
+		for range capacity - n {
+			if err := f.RemoveRow(sheet, y1+n+1); err != nil {
+				return err
+			}
+		}
+	}
+	adjustChartRanges(f, sheet, y1, y2, n-capacity)
+
+	for r, obj := range rows {
+		ptrV := reflect.ValueOf(obj)
+		v := ptrV.Elem()
+		for i, col := range cols {
+			if col < 0 {
+				continue
+			}
+
+			field := v.Field(sc.fields[col])
+			cell, _ := excelize.CoordinatesToCellName(x1+i, y1+1+r)
+			if err := f.SetCellValue(sheet, cell, getUnderlyingValue(field)); err != nil {
+				return err
+			}
+
+			styleID := styles[i]
+			rule, err := sc.matchRule(col, ptrV, field)
+			if err != nil {
+				return err
+			}
+			if rule != nil {
+				if styleID, err = f.overlayStyle(styleID, rule.styleID); err != nil {
+					return err
+				}
+				f.usage.hit(rule)
+			}
+			if err := f.SetCellStyle(sheet, cell, cell, styleID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(rows) == 0 {
+		for i, col := range cols {
+			if col < 0 {
+				continue
+			}
+			cell, _ := excelize.CoordinatesToCellName(x1+i, y1+1)
+			if err := f.SetCellValue(sheet, cell, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// findTable looks up the table named name, which is case-insensitive, in all sheets of f.
+func findTable(f *File, name string) (string, *excelize.Table, error) {
+	for _, sheet := range f.GetSheetList() {
+		tables, err := f.GetTables(sheet)
+		if err != nil {
+			return "", nil, err
+		}
+		for i := range tables {
+			if strings.EqualFold(tables[i].Name, name) { // NOTE: Table names are case-insensitive.
+				return sheet, &tables[i], nil
+			}
+		}
+	}
+	return "", nil, ErrTableNotFound
+}
+
+func setTemplateCell(f *File, sheet string, col, row, styleID int, formula string, copyFormula bool) error {
+	cell, _ := excelize.CoordinatesToCellName(col, row)
+	if err := f.SetCellStyle(sheet, cell, cell, styleID); err != nil {
+		return err
+	}
+	if copyFormula && formula != "" {
+		return f.SetCellFormula(sheet, cell, formula)
+	}
+	return nil
+}
+
+func getCellValue(f *File, sheet string, col, row int) (string, error) {
+	cell, err := excelize.CoordinatesToCellName(col, row)
+	if err != nil {
+		return "", err
+	}
+	return f.GetCellValue(sheet, cell)
+}
+
+// rangeRefToCoordinates converts range reference such as "A1:C3" to coordinates (1, 1, 3, 3).
+func rangeRefToCoordinates(ref string) (x1, y1, x2, y2 int, err error) {
+	topLeft, bottomRight, _ := strings.Cut(ref, ":")
+	if x1, y1, err = excelize.CellNameToCoordinates(topLeft); err != nil {
+		return
+	}
+	if bottomRight == "" {
+		return x1, y1, x1, y1, nil
+	}
+	x2, y2, err = excelize.CellNameToCoordinates(bottomRight)
+	return
+}
+
+// chartRefPattern matches the range references of chart series, such as <c:f>Sheet1!$B$2:$B$5</c:f>.
+var chartRefPattern = regexp.MustCompile(`(<(?:c:)?f>)(.+?)!\$([A-Z]+)\$(\d+):\$([A-Z]+)\$(\d+)(</(?:c:)?f>)`)
+
+// adjustChartRanges extends (or shrinks) by delta rows the chart series ranges on sheet
+// that start inside rows [top, bottom] and end at bottom.
+//
+// NOTE: excelize.File.InsertRows and RemoveRow do not update chart series references.
+func adjustChartRanges(f *File, sheet string, top, bottom, delta int) {
+	if delta == 0 {
+		return
+	}
+
+	f.Pkg.Range(func(k, v any) bool {
+		if !strings.HasPrefix(k.(string), "xl/charts/chart") {
+			return true
+		}
+
+		content := chartRefPattern.ReplaceAllFunc(v.([]byte), func(m []byte) []byte {
+			sub := chartRefPattern.FindSubmatch(m)
+			name := strings.ReplaceAll(strings.Trim(string(sub[2]), "'"), "''", "'")
+			startRow, _ := strconv.Atoi(string(sub[4]))
+			endRow, _ := strconv.Atoi(string(sub[6]))
+			if name != sheet || startRow < top || bottom < startRow || endRow != bottom {
+				return m
+			}
+			return []byte(string(sub[1]) + string(sub[2]) + "!$" + string(sub[3]) + "$" + string(sub[4]) +
+				":$" + string(sub[5]) + "$" + strconv.Itoa(endRow+delta) + string(sub[7]))
+		})
+		f.Pkg.Store(k, content)
+		return true
+	})
+}
@@ -0,0 +1,107 @@
+package exceltable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func newTemplateFile(t *testing.T) (*File, int) {
+	t.Helper()
+
+	f, err := NewFile()
+	require.NoError(t, err)
+
+	for i, h := range []string{"氏名", "Memo", "年齢", "ID", "Double"} {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		require.NoError(t, f.SetCellValue("Sheet1", cell, h))
+	}
+	styleID, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	require.NoError(t, err)
+	require.NoError(t, f.SetCellStyle("Sheet1", "A2", "A3", styleID))
+	require.NoError(t, f.SetCellFormula("Sheet1", "E2", "Template[[#This Row],[年齢]]*2"))
+	require.NoError(t, f.AddTable("Sheet1", &excelize.Table{Range: "A1:E3", Name: "Template"}))
+	require.NoError(t, f.SetCellFormula("Sheet1", "G1", "SUM(C2:C3)"))
+	require.NoError(t, f.AddChart("Sheet1", "G3", &excelize.Chart{
+		Type:   excelize.Col,
+		Series: []excelize.ChartSeries{{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$3", Values: "Sheet1!$C$2:$C$3"}},
+	}))
+
+	return f, styleID
+}
+
+func TestFillTemplate(t *testing.T) {
+	f, styleID := newTemplateFile(t)
+	require.NoError(t, FillTemplate(f, "Template", persons))
+
+	tables, err := f.GetTables("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	assert.Equal(t, "A1:E4", tables[0].Range)
+
+	rows, err := f.GetRows("Sheet1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alice", "", "17", "ID-123456"}, rows[1][:4])
+	assert.Equal(t, []string{"Carol", "", "100", ""}, rows[3][:4])
+
+	// template style and formula.
+	got, err := f.GetCellStyle("Sheet1", "A4")
+	require.NoError(t, err)
+	assert.Equal(t, styleID, got)
+	formula, err := f.GetCellFormula("Sheet1", "E4")
+	require.NoError(t, err)
+	assert.Equal(t, "Template[[#This Row],[年齢]]*2", formula)
+
+	// rule style.
+	got, err = f.GetCellStyle("Sheet1", "C2")
+	require.NoError(t, err)
+	assert.Equal(t, fileRuleByTag(t, f, warnTag).styleID, got)
+
+	// rule style overlaid on template style.
+	got, err = f.GetCellStyle("Sheet1", "A2")
+	require.NoError(t, err)
+	style, err := f.GetStyle(got)
+	require.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	assert.Equal(t, []string{"AAFFAA"}, style.Fill.Color)
+
+	// references to the table range.
+	formula, err = f.GetCellFormula("Sheet1", "G1")
+	require.NoError(t, err)
+	assert.Equal(t, "SUM(C2:C4)", formula)
+	chart, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	require.True(t, ok)
+	assert.Contains(t, string(chart.([]byte)), "Sheet1!$C$2:$C$4")
+}
+
+func TestFillTemplate_Shrink(t *testing.T) {
+	f, _ := newTemplateFile(t)
+	require.NoError(t, FillTemplate(f, "Template", persons[:1]))
+
+	tables, err := f.GetTables("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	assert.Equal(t, "A1:E2", tables[0].Range)
+
+	chart, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	require.True(t, ok)
+	assert.Contains(t, string(chart.([]byte)), "Sheet1!$C$2:$C$2")
+}
+
+func TestFillTemplate_Negative(t *testing.T) {
+	f, _ := newTemplateFile(t)
+	err := FillTemplate(f, "Undefined", persons)
+	assert.Equal(t, ErrTableNotFound, err)
+}
+
+func TestFillTemplate_TableNameCase(t *testing.T) {
+	f, _ := newTemplateFile(t)
+	require.NoError(t, FillTemplate(f, "TEMPLATE", persons))
+
+	tables, err := f.GetTables("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	assert.Equal(t, "A1:E4", tables[0].Range)
+}
@@ -59,6 +59,18 @@ func (p person) IsOld() bool { // value receiver.
 	return 75 <= p.Age
 }
 
+func fileRuleByTag(t *testing.T, f *File, tag ruleTagType) *fileRule {
+	t.Helper()
+
+	for _, r := range f.rules {
+		if r.tag == tag {
+			return r
+		}
+	}
+	t.Fatalf("rule %q is not found", tag)
+	return nil
+}
+
 func TestMain(m *testing.M) {
 	setup()
 	code := m.Run()
@@ -78,4 +90,6 @@ func setup() {
 		newFaces := []string{"Alice"}
 		return slices.Contains(newFaces, name)
 	})
+
+	RegisterDescription("IsChild", "younger than 18") // description of predicate method.
 }
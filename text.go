@@ -0,0 +1,171 @@
+package exceltable
+
+import (
+	"io"
+	"reflect"
+	"strings"
+)
+
+// TextOption configures WriteMarkdown and WriteText.
+type TextOption func(*textOptions)
+
+type textOptions struct {
+	markers map[ruleTagType]string
+}
+
+// WithMarker sets the symbol prepended to the cells satisfying the rule of tag.
+// An empty symbol disables marking for the tag.
+//
+// By default, "⚠" is used for "warn" and "❌" for "error".
+func WithMarker(tag, symbol string) TextOption {
+	return func(o *textOptions) {
+		o.markers[tag] = symbol
+	}
+}
+
+func newTextOptions(opts []TextOption) *textOptions {
+	o := &textOptions{
+		markers: map[ruleTagType]string{
+			warnTag:  "⚠",
+			errorTag: "❌",
+		},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WriteMarkdown writes rows to w as a GitHub Flavored Markdown table.
+//
+// Header values are resolved in the same way as Sheet, and the cells satisfying rules are
+// marked with the symbols set by WithMarker.
+func WriteMarkdown[M any](w io.Writer, rows []*M, opts ...TextOption) error {
+	cells, err := textCells(rows, newTextOptions(opts))
+	if err != nil {
+		return err
+	}
+
+	escaper := strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+	for i := range cells {
+		for j := range cells[i] {
+			cells[i][j] = escaper.Replace(cells[i][j])
+		}
+	}
+	widths := columnWidths(cells, 3) // NOTE: The delimiter row needs at least 3 hyphens.
+
+	var sb strings.Builder
+	writeLine := func(cells []string, pad string) {
+		sb.WriteString("|")
+		for j, c := range cells {
+			sb.WriteString(" " + c + strings.Repeat(pad, widths[j]-stringWidth(c)) + " |")
+		}
+		sb.WriteString("\n")
+	}
+
+	writeLine(cells[0], " ")
+	delimiter := make([]string, len(widths))
+	writeLine(delimiter, "-")
+	for _, row := range cells[1:] {
+		writeLine(row, " ")
+	}
+
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteText writes rows to w as a fixed-width plain text table.
+//
+// Header values are resolved in the same way as Sheet, and the cells satisfying rules are
+// marked with the symbols set by WithMarker.
+func WriteText[M any](w io.Writer, rows []*M, opts ...TextOption) error {
+	cells, err := textCells(rows, newTextOptions(opts))
+	if err != nil {
+		return err
+	}
+
+	replacer := strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ")
+	for i := range cells {
+		for j := range cells[i] {
+			cells[i][j] = replacer.Replace(cells[i][j])
+		}
+	}
+	widths := columnWidths(cells, 1)
+
+	var sb strings.Builder
+	writeLine := func(cells []string) {
+		line := ""
+		for j, c := range cells {
+			if j > 0 {
+				line += "  "
+			}
+			line += c + strings.Repeat(" ", widths[j]-stringWidth(c))
+		}
+		sb.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+
+	writeLine(cells[0])
+	rule := make([]string, len(widths))
+	for j, width := range widths {
+		rule[j] = strings.Repeat("-", width)
+	}
+	writeLine(rule)
+	for _, row := range cells[1:] {
+		writeLine(row)
+	}
+
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+// textCells converts rows into text cells, the first row of which is the header.
+func textCells[M any](rows []*M, o *textOptions) ([][]string, error) {
+	sc, err := newDetachedSchema(reflect.TypeFor[M](), excelTag, csvTag)
+	if err != nil {
+		return nil, err
+	}
+
+	cells := make([][]string, 0, len(rows)+1)
+	header := make([]string, 0, sc.tableWidth)
+	for _, h := range sc.header {
+		header = append(header, h.(string))
+	}
+	cells = append(cells, header)
+
+	for _, obj := range rows {
+		ptrV := reflect.ValueOf(obj)
+		v := ptrV.Elem()
+
+		row := make([]string, 0, sc.tableWidth)
+		for col, i := range sc.fields {
+			field := v.Field(i)
+			rule, err := sc.matchRule(col, ptrV, field)
+			if err != nil {
+				return nil, err
+			}
+
+			value := formatValue(field)
+			if rule != nil && o.markers[rule.tag] != "" {
+				value = strings.TrimSpace(o.markers[rule.tag] + " " + value)
+			}
+			row = append(row, value)
+		}
+		cells = append(cells, row)
+	}
+
+	return cells, nil
+}
+
+// columnWidths returns the display width of each column, which is at least minWidth.
+func columnWidths(cells [][]string, minWidth int) []int {
+	widths := make([]int, len(cells[0]))
+	for i := range widths {
+		widths[i] = minWidth
+	}
+	for _, row := range cells {
+		for j, c := range row {
+			widths[j] = max(widths[j], stringWidth(c))
+		}
+	}
+	return widths
+}
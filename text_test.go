@@ -0,0 +1,34 @@
+package exceltable
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteMarkdown(&buf, persons, WithMarker("newface", "★")))
+
+	want := "" +
+		"| ID        | 氏名    | 年齢  | 住所   | SpecialID    |\n" +
+		"| --------- | ------- | ----- | ------ | ------------ |\n" +
+		"| ID-123456 | ★ Alice | ⚠ 17  |        |              |\n" +
+		"| ID-112358 | Bob     | 32    | Boston | ❌           |\n" +
+		"| ❌        | Carol   | ⚠ 100 | 京都   | ⚠ SID-999999 |\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteText(&buf, persons[1:], WithMarker(errorTag, "!")))
+
+	want := "" +
+		"ID         氏名   年齢   住所    SpecialID\n" +
+		"---------  -----  -----  ------  ------------\n" +
+		"ID-112358  Bob    32     Boston  !\n" +
+		"!          Carol  ⚠ 100  京都    ⚠ SID-999999\n"
+	assert.Equal(t, want, buf.String())
+}
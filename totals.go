@@ -0,0 +1,146 @@
+package exceltable
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// totalTag is the tag selecting the aggregation of the totals row, e.g. `total:"sum"`.
+// Values other than the aggregation functions are written as labels, e.g. `total:"Total"`.
+const totalTag string = "total"
+
+// subtotalFunctions maps the aggregation functions of the totals row to the function numbers of
+// SUBTOTAL, which ignore rows hidden by filters.
+var subtotalFunctions = map[string]int{
+	"average":   101,
+	"countNums": 102,
+	"count":     103,
+	"max":       104,
+	"min":       105,
+	"stdDev":    107,
+	"sum":       109,
+	"var":       110,
+}
+
+var (
+	tableRefPattern       = regexp.MustCompile(`(<table [^>]*? ref="[^"]*")`)
+	autoFilterRefPattern  = regexp.MustCompile(`<autoFilter ref="[^"]*"`)
+	totalsRowCountPattern = regexp.MustCompile(`<table [^>]*?totalsRowCount="(\d+)"`)
+)
+
+// totals returns the total of each column given by the total tag, or nil if there is none.
+func totals(sc *schema) []string {
+	var totals []string
+	for col, i := range sc.fields {
+		total := sc.typ.Field(i).Tag.Get(totalTag)
+		if total == "" {
+			continue
+		}
+		if totals == nil {
+			totals = make([]string, sc.tableWidth)
+		}
+		totals[col] = total
+	}
+	return totals
+}
+
+// totalsRow returns the cells of the totals row:
+// SUBTOTAL formulas for aggregation functions, and strings for labels.
+func (s *sheetBase[M]) totalsRow() []*excelize.Cell {
+	cells := make([]*excelize.Cell, s.tableWidth)
+	for col, total := range s.totals {
+		cells[col] = &excelize.Cell{}
+		if n, ok := subtotalFunctions[total]; ok {
+			ref := fmt.Sprintf("%s[%s]", s.tableName(), structuredRefEscaper.Replace(s.header[col].(string)))
+			cells[col].Formula = fmt.Sprintf("SUBTOTAL(%d,%s)", n, ref)
+		} else if total != "" {
+			cells[col].Value = total
+		}
+	}
+	return cells
+}
+
+// addTotalsRow declares the totals row in the table definition written by AddTable.
+//
+// NOTE: excelize.Table does not support totals rows, so the table part is patched.
+func (s *sheetBase[M]) addTotalsRow() error {
+	if s.totals == nil {
+		return nil
+	}
+
+	part, content := s.File.tablePart(s.tableName())
+	if part == "" {
+		return ErrTableNotFound
+	}
+
+	content = tableRefPattern.ReplaceAll(content, []byte(`$1 totalsRowCount="1"`))
+	content = autoFilterRefPattern.ReplaceAll(content, []byte(fmt.Sprintf(`<autoFilter ref="%s:%s"`,
+		s.coordinatesToCellName(0, 0), s.coordinatesToCellName(max(s.tableWidth-1, 1), max(s.row-1, 1)))))
+	for col, total := range s.totals {
+		if total == "" {
+			continue
+		}
+
+		attr := fmt.Sprintf(`totalsRowFunction="%s"`, total)
+		if _, ok := subtotalFunctions[total]; !ok {
+			var buf bytes.Buffer
+			if err := xml.EscapeText(&buf, []byte(total)); err != nil {
+				return err
+			}
+			attr = fmt.Sprintf(`totalsRowLabel="%s"`, buf.String())
+		}
+		pattern := regexp.MustCompile(`(<tableColumn id="` + strconv.Itoa(col+1) + `" name="[^"]*")`)
+		content = pattern.ReplaceAll(content, []byte("$1 "+strings.ReplaceAll(attr, "$", "$$")))
+	}
+	s.File.Pkg.Store(part, content)
+	return nil
+}
+
+// tablePart returns the path and the content of the part of the table with the display name table,
+// or an empty path if it is not found.
+func (f *File) tablePart(table string) (string, []byte) {
+	name := []byte(fmt.Sprintf(`displayName="%s"`, table))
+	var part string
+	var content []byte
+	f.Pkg.Range(func(k, v any) bool {
+		if strings.HasPrefix(k.(string), "xl/tables/table") && bytes.Contains(v.([]byte), name) {
+			part, content = k.(string), v.([]byte)
+			return false
+		}
+		return true
+	})
+	return part, content
+}
+
+// dataRowCount returns the number of data rows of the table on the sheet name whose header row starts at cell (x, y),
+// excluding the totals row, or -1 if there is no such table with the totals row.
+func dataRowCount(f *File, name string, x, y int) (int, error) {
+	tables, err := f.GetTables(name)
+	if err != nil {
+		return 0, err
+	}
+	for _, table := range tables {
+		x1, y1, _, y2, err := rangeRefToCoordinates(table.Range)
+		if err != nil {
+			return 0, err
+		}
+		if x1 != x || y1 != y {
+			continue
+		}
+
+		_, content := f.tablePart(table.Name)
+		m := totalsRowCountPattern.FindSubmatch(content)
+		if m == nil {
+			return -1, nil
+		}
+		n, _ := strconv.Atoi(string(m[1]))
+		return y2 - y1 - n, nil
+	}
+	return -1, nil
+}
@@ -0,0 +1,185 @@
+package exceltable
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type invoiceLine struct {
+	Item   string   `excel:"Item" total:"Total"`
+	Qty    int      `excel:"Qty" total:"sum"`
+	Price  float64  `excel:"Price" total:"average"`
+	_      struct{} `excel:"Amount" excelformula:"{@Qty}*{@Price}" total:"sum"`
+	Remark string   `excel:"Remark"`
+}
+
+var invoiceLines = []*invoiceLine{
+	{Item: "Pen", Qty: 3, Price: 120},
+	{Item: "Notebook", Qty: 2, Price: 250},
+}
+
+func tablePart(t *testing.T, f *File) string {
+	t.Helper()
+	v, ok := f.Pkg.Load("xl/tables/table1.xml")
+	require.True(t, ok)
+	return string(v.([]byte))
+}
+
+func TestSheet_TotalsRow(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[invoiceLine](f, "Invoice", "B2", true)
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetHeader())
+	for _, line := range invoiceLines {
+		require.NoError(t, s.SetRow(line))
+	}
+	require.NoError(t, s.AddDefaultTable())
+	require.NoError(t, s.Flush())
+
+	label, err := f.GetCellValue("Invoice", "B5")
+	require.NoError(t, err)
+	assert.Equal(t, "Total", label)
+	for cell, want := range map[string]string{
+		"C5": "SUBTOTAL(109,InvoiceTable[Qty])",
+		"D5": "SUBTOTAL(101,InvoiceTable[Price])",
+		"E5": "SUBTOTAL(109,InvoiceTable[Amount])",
+		"F5": "",
+	} {
+		formula, err := f.GetCellFormula("Invoice", cell)
+		require.NoError(t, err)
+		assert.Equal(t, want, formula, cell)
+	}
+
+	part := tablePart(t, f)
+	assert.Contains(t, part, `ref="B2:F5" totalsRowCount="1"`)
+	assert.Contains(t, part, `<autoFilter ref="B2:F4"`)
+	assert.Contains(t, part, `<tableColumn id="1" name="Item" totalsRowLabel="Total"`)
+	assert.Contains(t, part, `<tableColumn id="2" name="Qty" totalsRowFunction="sum"`)
+	assert.Contains(t, part, `<tableColumn id="3" name="Price" totalsRowFunction="average"`)
+	assert.Contains(t, part, `<tableColumn id="4" name="Amount" totalsRowFunction="sum"`)
+	assert.Regexp(t, regexp.MustCompile(`<tableColumn id="5" name="Remark"\s*>`), part)
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	saved, err := OpenFile(path)
+	require.NoError(t, err)
+	tables, err := saved.GetTables("Invoice")
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	assert.Equal(t, "B2:F5", tables[0].Range)
+}
+
+func TestSheetWithStreamWriter_TotalsRow(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	ssw, err := NewSheetWithStreamWriter[invoiceLine](f, "Invoice", "A1", true)
+	require.NoError(t, err)
+
+	require.NoError(t, ssw.SetHeader())
+	for _, line := range invoiceLines {
+		require.NoError(t, ssw.SetRow(line))
+	}
+	require.NoError(t, ssw.AddDefaultTable())
+	require.NoError(t, ssw.Flush())
+
+	formula, err := f.GetCellFormula("Invoice", "B4")
+	require.NoError(t, err)
+	assert.Equal(t, "SUBTOTAL(109,InvoiceTable[Qty])", formula)
+
+	part := tablePart(t, f)
+	assert.Contains(t, part, `ref="A1:E4" totalsRowCount="1"`)
+	assert.Contains(t, part, `<autoFilter ref="A1:E3"`)
+}
+
+func TestSheet_NoTotalsRow(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[person](f, "test", "A1", true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetHeader())
+	require.NoError(t, s.SetRow(persons[0]))
+	require.NoError(t, s.AddDefaultTable())
+
+	assert.NotContains(t, tablePart(t, f), "totalsRow")
+}
+
+func TestSheet_TotalsRowNoOverlap(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	l, err := NewSheetLayout(f, "Invoice", "A1", true)
+	require.NoError(t, err)
+
+	empty, err := PlaceSheet[invoiceLine](l)
+	require.NoError(t, err)
+	require.NoError(t, empty.SetHeader())
+	require.NoError(t, empty.AddDefaultTable())
+	assert.ErrorIs(t, empty.SetRow(invoiceLines[0]), ErrTableOverlap)
+
+	lines, err := PlaceSheet[invoiceLine](l)
+	require.NoError(t, err)
+	require.NoError(t, lines.SetHeader())
+	for _, line := range invoiceLines {
+		require.NoError(t, lines.SetRow(line))
+	}
+	require.NoError(t, lines.AddDefaultTable())
+
+	detail, err := PlaceSheet[person](l)
+	require.NoError(t, err)
+	require.NoError(t, detail.SetHeader())
+	require.NoError(t, l.Flush())
+
+	for cell, want := range map[string]string{
+		"A2":  "",
+		"A3":  "Total",
+		"A5":  "Item",
+		"A8":  "Total",
+		"A10": "ID",
+	} {
+		value, err := f.GetCellValue("Invoice", cell)
+		require.NoError(t, err)
+		assert.Equal(t, want, value, cell)
+	}
+	tables, err := f.GetTables("Invoice")
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+	assert.Equal(t, "A1:E3", tables[0].Range)
+	assert.Equal(t, "A5:E8", tables[1].Range)
+}
+
+func TestSheetWithStreamWriter_TotalsRowNoOverlap(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	l, err := NewSheetLayout(f, "Invoice", "A1", true)
+	require.NoError(t, err)
+
+	lines, err := PlaceSheetWithStreamWriter[invoiceLine](l)
+	require.NoError(t, err)
+	require.NoError(t, lines.SetHeader())
+	for _, line := range invoiceLines {
+		require.NoError(t, lines.SetRow(line))
+	}
+	require.NoError(t, lines.AddDefaultTable())
+	assert.ErrorIs(t, lines.SetRow(invoiceLines[0]), ErrTableOverlap)
+
+	detail, err := PlaceSheetWithStreamWriter[person](l)
+	require.NoError(t, err)
+	require.NoError(t, detail.SetHeader())
+	require.NoError(t, l.Flush())
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	saved, err := OpenFile(path)
+	require.NoError(t, err)
+	rows, err := saved.GetRows("Invoice")
+	require.NoError(t, err)
+	require.Len(t, rows, 6)
+	assert.Equal(t, "Total", rows[3][0])
+	assert.Empty(t, rows[4])
+	assert.Equal(t, "ID", rows[5][0])
+}
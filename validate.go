@@ -0,0 +1,107 @@
+package exceltable
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Finding represents a field satisfying the predicate of a rule.
+type Finding struct {
+	Row       int    `json:"row"`       // index of the row in rows
+	Field     string `json:"field"`     // struct field name
+	Header    string `json:"header"`    // header value
+	Rule      string `json:"rule"`      // rule tag
+	Predicate string `json:"predicate"` // predicate key
+	Value     any    `json:"value"`     // underlying field value
+}
+
+// Report is the result of Validate.
+type Report struct {
+	Rows     int                       `json:"rows"`     // number of rows
+	Findings []Finding                 `json:"findings"` // findings in order of rows and columns
+	ByRule   map[string]int            `json:"byRule"`   // number of findings per rule tag
+	ByColumn map[string]map[string]int `json:"byColumn"` // number of findings per header value and rule tag
+}
+
+// Count returns the number of findings of the rule tag.
+func (r *Report) Count(tag string) int {
+	return r.ByRule[tag]
+}
+
+// ValidateOption configures Validate.
+type ValidateOption func(*validateOptions)
+
+type validateOptions struct {
+	tags []string
+}
+
+// WithRuleTags restricts the rules evaluated by Validate to the given tags.
+func WithRuleTags(tags ...string) ValidateOption {
+	return func(o *validateOptions) {
+		o.tags = append(o.tags, tags...)
+	}
+}
+
+// Validate evaluates every registered rule against every field of rows without writing any output,
+// and returns the structured findings.
+//
+// Unlike Sheet.SetRow, which applies only the style of the first satisfied rule,
+// a field is reported once for each rule tag it satisfies.
+func Validate[M any](rows []*M, opts ...ValidateOption) (*Report, error) {
+	o := &validateOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	sc, err := newDetachedSchema(reflect.TypeFor[M](), excelTag, csvTag)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Rows:     len(rows),
+		Findings: make([]Finding, 0),
+		ByRule:   make(map[string]int),
+		ByColumn: make(map[string]map[string]int),
+	}
+	for r, obj := range rows {
+		ptrV := reflect.ValueOf(obj)
+		v := ptrV.Elem()
+		for col, i := range sc.fields {
+			field := v.Field(i)
+			header := sc.header[col].(string)
+
+			matched := make(map[ruleTagType]bool)
+			for _, rule := range sc.rulesList[col] {
+				if matched[rule.tag] || (len(o.tags) > 0 && !slices.Contains(o.tags, rule.tag)) {
+					continue
+				}
+
+				b, err := callPredicate(rule.bind(ptrV), field)
+				if err != nil {
+					return nil, err
+				}
+				if !b {
+					continue
+				}
+
+				matched[rule.tag] = true // NOTE: Predicates of the same tag are OR condition.
+				report.Findings = append(report.Findings, Finding{
+					Row:       r,
+					Field:     sc.typ.Field(i).Name,
+					Header:    header,
+					Rule:      rule.tag,
+					Predicate: rule.key,
+					Value:     getUnderlyingValue(field),
+				})
+				report.ByRule[rule.tag]++
+				if report.ByColumn[header] == nil {
+					report.ByColumn[header] = make(map[string]int)
+				}
+				report.ByColumn[header][rule.tag]++
+			}
+		}
+	}
+
+	return report, nil
+}
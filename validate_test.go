@@ -0,0 +1,44 @@
+package exceltable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	report, err := Validate(persons)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, report.Rows)
+	assert.Equal(t, 3, report.Count(warnTag))
+	assert.Equal(t, 2, report.Count(errorTag))
+	assert.Equal(t, 1, report.Count("newface"))
+	assert.Equal(t, map[string]int{warnTag: 1, errorTag: 1}, report.ByColumn["SpecialID"])
+
+	want := Finding{Row: 2, Field: "Age", Header: "年齢", Rule: warnTag, Predicate: "IsOld", Value: 100}
+	assert.Contains(t, report.Findings, want)
+
+	b, err := json.Marshal(report.Findings[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"row":0,"field":"Name","header":"氏名","rule":"newface","predicate":"isNewFace","value":"Alice"}`, string(b))
+}
+
+func TestValidate_WithRuleTags(t *testing.T) {
+	report, err := Validate(persons, WithRuleTags(errorTag))
+	require.NoError(t, err)
+
+	want := map[string]int{errorTag: 2}
+	if diff := cmp.Diff(want, report.ByRule); diff != "" {
+		t.Errorf("Validate(...) mismatch (-want +got):\n%s", diff)
+	}
+	assert.Equal(t, "nil", report.Findings[0].Predicate)
+}
+
+func TestValidate_Negative(t *testing.T) {
+	_, err := Validate([]*int{})
+	assert.Equal(t, ErrNotStructType, err)
+}
@@ -0,0 +1,160 @@
+package exceltable
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Tags indicating data validation.
+const (
+	enumTag      string = "enum"      // drop-down list, e.g. `enum:"A,B,C"`
+	rangeTag     string = "range"     // numeric range, e.g. `range:"0,150"`, `range:"0,"`
+	dateRangeTag string = "daterange" // date range, e.g. `daterange:"2020-01-01,2030-12-31"`
+	maxLenTag    string = "maxlen"    // maximum text length, e.g. `maxlen:"20"`
+	promptTag    string = "prompt"    // input message
+	errMsgTag    string = "errmsg"    // error message
+)
+
+// enums is a map of registered enum values with type.
+// pair of (reflect.Type, []string).
+var enums sync.Map
+
+// RegisterEnum registers the values of enum type T.
+// Fields of type T (or *T) get the drop-down list of the values as data validation:
+//
+//	type Status string
+//	exceltable.RegisterEnum[Status]("active", "inactive")
+func RegisterEnum[T any](values ...T) {
+	keys := make([]string, 0, len(values))
+	for _, v := range values {
+		keys = append(keys, fmt.Sprint(v))
+	}
+	enums.Store(reflect.TypeFor[T](), keys)
+}
+
+// newDataValidation creates the data validation described by the tags of field,
+// or returns nil if there is none. Sqref is left empty.
+func newDataValidation(field reflect.StructField, header string) (*excelize.DataValidation, error) {
+	dv := excelize.NewDataValidation(true)
+
+	t := field.Type
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	n := 0
+	if enum := field.Tag.Get(enumTag); enum != "" {
+		if err := dv.SetDropList(strings.Split(enum, ",")); err != nil {
+			return nil, err
+		}
+		n++
+	} else if keys, ok := enums.Load(t); ok {
+		if err := dv.SetDropList(keys.([]string)); err != nil {
+			return nil, err
+		}
+		n++
+	}
+
+	if rng := field.Tag.Get(rangeTag); rng != "" {
+		validationType := excelize.DataValidationTypeDecimal
+		if isIntegerKind(t.Kind()) {
+			validationType = excelize.DataValidationTypeWhole
+		}
+		if err := setDataValidationRange(dv, rng, validationType, func(s string) (string, error) {
+			if _, err := strconv.ParseFloat(s, 64); err != nil {
+				return "", err
+			}
+			return s, nil
+		}); err != nil {
+			return nil, err
+		}
+		n++
+	}
+
+	if rng := field.Tag.Get(dateRangeTag); rng != "" {
+		if err := setDataValidationRange(dv, rng, excelize.DataValidationTypeDate, func(s string) (string, error) {
+			d, err := time.Parse(time.DateOnly, s)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("DATE(%d,%d,%d)", d.Year(), d.Month(), d.Day()), nil
+		}); err != nil {
+			return nil, err
+		}
+		n++
+	}
+
+	if maxLen := field.Tag.Get(maxLenTag); maxLen != "" {
+		if _, err := strconv.Atoi(maxLen); err != nil {
+			return nil, err
+		}
+		if err := dv.SetRange(maxLen, "", excelize.DataValidationTypeTextLength, excelize.DataValidationOperatorLessThanOrEqual); err != nil {
+			return nil, err
+		}
+		n++
+	}
+
+	switch n {
+	case 0:
+		return nil, nil
+	case 1:
+		// ok
+	default:
+		return nil, ErrInvalidValidation // NOTE: A cell can have only one data validation.
+	}
+
+	if prompt := field.Tag.Get(promptTag); prompt != "" {
+		dv.SetInput(header, prompt)
+	}
+	if errMsg := field.Tag.Get(errMsgTag); errMsg != "" {
+		dv.SetError(excelize.DataValidationErrorStyleStop, header, errMsg)
+	} else {
+		dv.ShowErrorMessage = true
+	}
+
+	return dv, nil
+}
+
+// setDataValidationRange sets the range written as "min,max" to dv. Either bound may be omitted.
+func setDataValidationRange(dv *excelize.DataValidation, rng string, t excelize.DataValidationType, formula func(string) (string, error)) error {
+	lower, upper, ok := strings.Cut(rng, ",")
+	if !ok || (lower == "" && upper == "") {
+		return ErrInvalidValidation
+	}
+
+	var f1, f2 string
+	var err error
+	if lower != "" {
+		if f1, err = formula(strings.TrimSpace(lower)); err != nil {
+			return err
+		}
+	}
+	if upper != "" {
+		if f2, err = formula(strings.TrimSpace(upper)); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case f1 == "":
+		return dv.SetRange(f2, "", t, excelize.DataValidationOperatorLessThanOrEqual)
+	case f2 == "":
+		return dv.SetRange(f1, "", t, excelize.DataValidationOperatorGreaterThanOrEqual)
+	}
+	return dv.SetRange(f1, f2, t, excelize.DataValidationOperatorBetween)
+}
+
+func isIntegerKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
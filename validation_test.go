@@ -0,0 +1,134 @@
+package exceltable
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+type status string
+
+type application struct {
+	Name     string    `excel:"Name" maxlen:"20" errmsg:"Up to 20 characters."`
+	Plan     string    `excel:"Plan" enum:"Free,Pro,Enterprise" prompt:"Choose a plan."`
+	Status   status    `excel:"Status"`
+	Seats    int       `excel:"Seats" range:"1,100"`
+	Discount float64   `excel:"Discount" range:",0.5"`
+	Start    time.Time `excel:"Start" daterange:"2020-01-01,2030-12-31"`
+	Note     string    `excel:"Note"`
+}
+
+var applications = []*application{
+	{Name: "Alice", Plan: "Pro", Status: "active", Seats: 5, Discount: 0.1, Start: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+	{Name: "Bob", Plan: "Free", Status: "inactive", Seats: 1, Start: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+}
+
+func TestNewDataValidation(t *testing.T) {
+	RegisterEnum[status]("active", "inactive")
+	t.Cleanup(func() { enums.Delete(reflect.TypeFor[status]()) })
+
+	typ := reflect.TypeFor[application]()
+	tests := []struct {
+		field string
+		want  *excelize.DataValidation
+	}{
+		{"Name", &excelize.DataValidation{
+			AllowBlank: true, ShowErrorMessage: true, ErrorStyle: &[]string{"stop"}[0], ErrorTitle: &[]string{"Name"}[0], Error: &[]string{"Up to 20 characters."}[0],
+			Type: "textLength", Operator: "lessThanOrEqual", Formula1: "20",
+		}},
+		{"Plan", &excelize.DataValidation{
+			AllowBlank: true, ShowErrorMessage: true, ShowInputMessage: true, PromptTitle: &[]string{"Plan"}[0], Prompt: &[]string{"Choose a plan."}[0],
+			Type: "list", Formula1: `"Free,Pro,Enterprise"`,
+		}},
+		{"Status", &excelize.DataValidation{
+			AllowBlank: true, ShowErrorMessage: true,
+			Type: "list", Formula1: `"active,inactive"`,
+		}},
+		{"Seats", &excelize.DataValidation{
+			AllowBlank: true, ShowErrorMessage: true,
+			Type: "whole", Operator: "between", Formula1: "1", Formula2: "100",
+		}},
+		{"Discount", &excelize.DataValidation{
+			AllowBlank: true, ShowErrorMessage: true,
+			Type: "decimal", Operator: "lessThanOrEqual", Formula1: "0.5",
+		}},
+		{"Start", &excelize.DataValidation{
+			AllowBlank: true, ShowErrorMessage: true,
+			Type: "date", Operator: "between", Formula1: "DATE(2020,1,1)", Formula2: "DATE(2030,12,31)",
+		}},
+		{"Note", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			field, _ := typ.FieldByName(tt.field)
+			got, err := newDataValidation(field, tt.field)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewDataValidation_Invalid(t *testing.T) {
+	type invalid struct {
+		Conflict string `enum:"A,B" maxlen:"3"`
+		NoComma  int    `range:"10"`
+		BadDate  string `daterange:"2020/01/01,"`
+	}
+
+	typ := reflect.TypeFor[invalid]()
+	_, err := newDataValidation(typ.Field(0), "Conflict")
+	assert.ErrorIs(t, err, ErrInvalidValidation)
+	_, err = newDataValidation(typ.Field(1), "NoComma")
+	assert.ErrorIs(t, err, ErrInvalidValidation)
+	_, err = newDataValidation(typ.Field(2), "BadDate")
+	assert.Error(t, err)
+
+	f, err := NewFile()
+	require.NoError(t, err)
+	_, err = NewSheet[invalid](f, "test", "A1", true)
+	assert.ErrorIs(t, err, ErrInvalidValidation)
+}
+
+func TestSheet_DataValidation(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[application](f, "test", "B2", true)
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetHeader())
+	for _, a := range applications {
+		require.NoError(t, s.SetRow(a))
+	}
+	require.NoError(t, s.Flush())
+
+	dvs, err := f.GetDataValidations("test")
+	require.NoError(t, err)
+	sqrefs := make([]string, 0, len(dvs))
+	for _, dv := range dvs {
+		sqrefs = append(sqrefs, dv.Sqref)
+	}
+	assert.Equal(t, []string{"B3:B4", "C3:C4", "E3:E4", "F3:F4", "G3:G4"}, sqrefs)
+}
+
+func TestSheetWithStreamWriter_DataValidation(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	ssw, err := NewSheetWithStreamWriter[application](f, "test", "A1", true)
+	require.NoError(t, err)
+
+	require.NoError(t, ssw.SetHeader())
+	for _, a := range applications {
+		require.NoError(t, ssw.SetRow(a))
+	}
+	require.NoError(t, ssw.Flush())
+
+	dvs, err := f.GetDataValidations("test")
+	require.NoError(t, err)
+	require.Len(t, dvs, 5)
+	assert.Equal(t, "D2:D3", dvs[2].Sqref)
+	assert.Equal(t, "whole", dvs[2].Type)
+}
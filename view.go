@@ -0,0 +1,61 @@
+package exceltable
+
+import "github.com/xuri/excelize/v2"
+
+// autoWidthSampleRows is the number of data rows held back by SheetWithStreamWriter to compute column widths.
+const autoWidthSampleRows = 100
+
+// autoWidthPadding is the extra width for the margins and the filter button.
+const autoWidthPadding = 2
+
+// freezeHeader freezes the rows down to the header row if WithFreezeHeader is specified.
+func (s *sheetBase[M]) freezeHeader() error {
+	if !s.opts.freezeHeader {
+		return nil
+	}
+
+	topLeftCell, err := excelize.CoordinatesToCellName(1, s.y+1)
+	if err != nil {
+		return err
+	}
+	return s.File.SetPanes(s.name, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      s.y,
+		TopLeftCell: topLeftCell,
+		ActivePane:  "bottomLeft",
+		Selection:   []excelize.Selection{{SQRef: topLeftCell, ActiveCell: topLeftCell, Pane: "bottomLeft"}},
+	})
+}
+
+// addAutoFilter turns on the autofilter if WithAutoFilter is specified and no table is added.
+func (s *sheetBase[M]) addAutoFilter() error {
+	if !s.opts.autoFilter || s.hasTable {
+		return nil
+	}
+
+	rangeRef := s.coordinatesToCellName(0, 0) + ":" + s.coordinatesToCellName(max(s.tableWidth-1, 0), max(s.row-1, 1))
+	return s.File.AutoFilter(s.name, rangeRef, nil)
+}
+
+// observeWidth records the width of value written in column col for WithAutoWidth.
+func (s *sheetBase[M]) observeWidth(col int, value string) {
+	if s.opts.autoWidth == nil {
+		return
+	}
+	s.widths[col] = max(s.widths[col], stringWidth(value))
+}
+
+// columnWidths returns the width of each column computed from the observed widths.
+// Hidden columns are excluded.
+func (s *sheetBase[M]) columnWidths() map[int]float64 {
+	hidden := hiddenColumns(s.schema)
+	widths := make(map[int]float64, s.tableWidth)
+	for col := range s.tableWidth {
+		w := max(s.widths[col], stringWidth(s.header[col].(string)))
+		widths[col] = min(max(float64(w+autoWidthPadding), s.opts.autoWidth.min), s.opts.autoWidth.max)
+	}
+	for _, col := range hidden {
+		delete(widths, col)
+	}
+	return widths
+}
@@ -0,0 +1,117 @@
+package exceltable
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type member struct {
+	ID   string `excel:"ID"`
+	Name string `excel:"名前"`
+	Note string `excel:"Note,hidden"`
+}
+
+var members = []*member{
+	{"M-1", "山田太郎", "x"},
+	{"M-2", "Bob", "y"},
+}
+
+func TestSheet_ViewOptions(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[member](f, "test", "B3", true, WithFreezeHeader(), WithAutoFilter(), WithAutoWidth(6, 10))
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetHeader())
+	for _, m := range members {
+		require.NoError(t, s.SetRow(m))
+	}
+	require.NoError(t, s.Flush())
+
+	panes, err := f.GetPanes("test")
+	require.NoError(t, err)
+	assert.True(t, panes.Freeze)
+	assert.Equal(t, 3, panes.YSplit)
+	assert.Equal(t, "A4", panes.TopLeftCell)
+
+	for col, want := range map[string]float64{"B": 6, "C": 10} { // "M-1" + 2 < 6, "山田太郎" + 2 > 10.
+		width, err := f.GetColWidth("test", col)
+		require.NoError(t, err)
+		assert.Equal(t, want, width, col)
+	}
+	visible, err := f.GetColVisible("test", "D")
+	require.NoError(t, err)
+	assert.False(t, visible)
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	saved, err := OpenFile(path)
+	require.NoError(t, err)
+	names := saved.GetDefinedName()
+	require.Len(t, names, 1)
+	assert.Equal(t, "_xlnm._FilterDatabase", names[0].Name)
+	assert.Equal(t, "'test'!$B$3:$D$5", names[0].RefersTo)
+}
+
+func TestSheet_WithAutoFilter_Table(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	s, err := NewSheet[member](f, "test", "A1", true, WithAutoFilter())
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetHeader())
+	require.NoError(t, s.SetRow(members[0]))
+	require.NoError(t, s.AddDefaultTable())
+	require.NoError(t, s.Flush())
+	assert.Empty(t, f.GetDefinedName())
+}
+
+func TestSheetWithStreamWriter_ViewOptions(t *testing.T) {
+	f, err := NewFile()
+	require.NoError(t, err)
+	ssw, err := NewSheetWithStreamWriter[member](f, "test", "A1", true, WithFreezeHeader(), WithAutoFilter(), WithAutoWidth(0, 100))
+	require.NoError(t, err)
+
+	require.NoError(t, ssw.SetHeader())
+	for i := range autoWidthSampleRows + 10 {
+		name := "Bob"
+		if i == autoWidthSampleRows+5 {
+			name = "Too long to be sampled"
+		}
+		require.NoError(t, ssw.SetRow(&member{ID: fmt.Sprintf("M-%03d", i), Name: name}))
+		if i < autoWidthSampleRows {
+			assert.Len(t, ssw.pending, i+2)
+		}
+	}
+	assert.Empty(t, ssw.pending)
+	require.NoError(t, ssw.Flush())
+
+	path := filepath.Join(t.TempDir(), "test.xlsx")
+	require.NoError(t, f.SaveAs(path))
+	saved, err := OpenFile(path)
+	require.NoError(t, err)
+
+	panes, err := saved.GetPanes("test")
+	require.NoError(t, err)
+	assert.True(t, panes.Freeze)
+	assert.Equal(t, "A2", panes.TopLeftCell)
+
+	for col, want := range map[string]float64{"A": 7, "B": 6} { // "M-000" + 2, "名前" + 2 (sampled rows only).
+		width, err := saved.GetColWidth("test", col)
+		require.NoError(t, err)
+		assert.Equal(t, want, width, col)
+	}
+
+	rows, err := saved.GetRows("test")
+	require.NoError(t, err)
+	assert.Len(t, rows, autoWidthSampleRows+11)
+	assert.Equal(t, "Too long to be sampled", rows[autoWidthSampleRows+6][1])
+
+	names := saved.GetDefinedName()
+	require.Len(t, names, 1)
+	assert.Equal(t, "'test'!$A$1:$C$111", names[0].RefersTo)
+}
@@ -0,0 +1,25 @@
+package exceltable
+
+import "testing"
+
+func Test_stringWidth(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want int
+	}{
+		{arg: "", want: 0},
+		{arg: "Alice", want: 5},
+		{arg: "氏名", want: 4},
+		{arg: "ｱｲｳ", want: 3}, // halfwidth katakana.
+		{arg: "ＡＢ", want: 4},  // fullwidth alphabet.
+		{arg: "⚠ ❌", want: 4},
+		{arg: "が", want: 2}, // with combining mark.
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			if got := stringWidth(tt.arg); got != tt.want {
+				t.Errorf("stringWidth(%q) = %v, want %v", tt.arg, got, tt.want)
+			}
+		})
+	}
+}